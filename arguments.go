@@ -0,0 +1,85 @@
+package messageformat
+
+// An ArgumentKind tells how an argument is used by a message, and so which values it expects.
+type ArgumentKind int
+
+const (
+	// VarArgument is a simple argument (i.e. "{name}"), rendered as a string.
+	VarArgument ArgumentKind = iota
+	// SelectArgument is the key of a "select" argument.
+	SelectArgument
+	// PluralArgument is the number of a "plural" argument.
+	PluralArgument
+	// OrdinalArgument is the number of a "selectordinal" argument.
+	OrdinalArgument
+	// CustomArgument is an argument of any other type (i.e. "number", "date", "choice"), named by Argument.Type.
+	CustomArgument
+)
+
+// An Argument is a use of an argument by a message.
+type Argument struct {
+	Name string
+	Kind ArgumentKind
+	Type string // type of a CustomArgument
+}
+
+// String returns the name of the kind.
+func (x ArgumentKind) String() string {
+	switch x {
+	case VarArgument:
+		return "var"
+
+	case SelectArgument:
+		return "select"
+
+	case PluralArgument:
+		return "plural"
+
+	case OrdinalArgument:
+		return "selectordinal"
+	}
+	return "custom"
+}
+
+// Arguments returns the arguments used anywhere in the message, including the choices of the nested arguments,
+// in the input order.
+//
+// An argument used several times with the same kind (and type) is listed once.
+func (x *MessageFormat) Arguments() []Argument {
+	var result []Argument
+	seen := make(map[Argument]bool)
+
+	Walk(x.AST(), func(n Node) bool {
+		var argument Argument
+
+		switch t := n.(type) {
+		default:
+			return true
+
+		case *ArgumentNode:
+			argument = Argument{t.Name, VarArgument, ""}
+
+		case *SelectNode:
+			argument = Argument{t.Name, SelectArgument, ""}
+
+		case *PluralNode:
+			argument = Argument{t.Name, PluralArgument, ""}
+
+		case *OrdinalNode:
+			argument = Argument{t.Name, OrdinalArgument, ""}
+
+		case *ChoiceNode:
+			argument = Argument{t.Name, CustomArgument, "choice"}
+
+		case *CustomNode:
+			argument = Argument{t.Name, CustomArgument, t.Type}
+		}
+
+		if !seen[argument] {
+			seen[argument] = true
+			result = append(result, argument)
+		}
+		return true
+	})
+	return result
+}
@@ -0,0 +1,41 @@
+package messageformat
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArguments(t *testing.T) {
+	mf, err := doParse(`{NAME} {G, select, male{{N, plural, one{# {NAME}} other{{N, number} {C, choice, 0#|1<{R, selectordinal, other{#}}}}}} other{{N} {D, date, short} {user.name}}}`)
+	if err != nil {
+		t.Errorf("Unexpected parse failure: `%s`", err.Error())
+		return
+	}
+
+	expected := []Argument{
+		{"NAME", VarArgument, ""},
+		{"G", SelectArgument, ""},
+		{"N", PluralArgument, ""},
+		{"N", CustomArgument, "number"},
+		{"C", CustomArgument, "choice"},
+		{"R", OrdinalArgument, ""},
+		{"N", VarArgument, ""},
+		{"D", CustomArgument, "date"},
+		{"user.name", VarArgument, ""},
+	}
+
+	if result := mf.Arguments(); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expecting <%v> but got <%v>", expected, result)
+	}
+
+	mf, _ = doParse("Hello world")
+	if result := mf.Arguments(); len(result) != 0 {
+		t.Errorf("Expecting no argument but got <%v>", result)
+	}
+
+	for kind, name := range map[ArgumentKind]string{VarArgument: "var", SelectArgument: "select", PluralArgument: "plural", OrdinalArgument: "selectordinal", CustomArgument: "custom"} {
+		if kind.String() != name {
+			t.Errorf("Expecting <%s> but got <%s>", name, kind)
+		}
+	}
+}
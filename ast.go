@@ -0,0 +1,213 @@
+package messageformat
+
+import (
+	"sort"
+	"strings"
+)
+
+type (
+	// A Span locates a node in the parsed input, as offsets in runes.
+	Span struct {
+		Start, End int
+	}
+
+	// A Node is an element of the AST of a parsed message.
+	//
+	// It is one of *Message, *LiteralNode, *ArgumentNode, *SelectNode, *PluralNode, *OrdinalNode, *ChoiceNode
+	// or *CustomNode.
+	Node interface {
+		Offsets() (start, end int)
+		astNode()
+	}
+
+	// A Message is a sequence of literal texts and arguments: the whole input or the content of a choice.
+	Message struct {
+		Span
+		Nodes []Node
+	}
+
+	// A LiteralNode is an unescaped literal text.
+	//
+	// Each empty part of its Value stands for a "#", which renders the value of the closest "plural" or
+	// "selectordinal" argument.
+	LiteralNode struct {
+		Span
+		Value []string
+	}
+
+	// An ArgumentNode is a simple argument (i.e. "{name}").
+	ArgumentNode struct {
+		Span
+		Name string
+	}
+
+	// A SelectNode is a "select" argument (i.e. "{gender, select, male{...} other{...}}").
+	SelectNode struct {
+		Span
+		Name    string
+		Choices []*Choice
+	}
+
+	// A PluralNode is a "plural" argument (i.e. "{count, plural, offset:1 =0{...} one{...} other{...}}").
+	PluralNode struct {
+		Span
+		Name    string
+		Offset  int
+		Choices []*Choice
+	}
+
+	// An OrdinalNode is a "selectordinal" argument (i.e. "{rank, selectordinal, one{#st} other{#th}}").
+	OrdinalNode struct {
+		Span
+		Name    string
+		Choices []*Choice
+	}
+
+	// A Choice is a keyed message of a SelectNode, a PluralNode or an OrdinalNode, in the input order.
+	Choice struct {
+		Key     string
+		Message *Message
+	}
+
+	// A ChoiceNode is a legacy "choice" argument (i.e. "{count, choice, 0#none|1#one|1<many}").
+	ChoiceNode struct {
+		Span
+		Name   string
+		Limits []*ChoiceLimit
+	}
+
+	// A ChoiceLimit is a message of a ChoiceNode, selected when the value reaches its limit.
+	ChoiceLimit struct {
+		Limit   float64
+		Strict  bool // true if the value must be greater than the limit ('<')
+		Message *Message
+	}
+
+	// A CustomNode is an argument of any other type (i.e. "{amount, number, currency}").
+	CustomNode struct {
+		Span
+		Name  string
+		Type  string
+		Style string // trimmed source following the type, if any
+	}
+)
+
+// Offsets returns the start and end offsets of the span.
+func (x Span) Offsets() (int, int) {
+	return x.Start, x.End
+}
+
+func (*Message) astNode()      {}
+func (*LiteralNode) astNode()  {}
+func (*ArgumentNode) astNode() {}
+func (*SelectNode) astNode()   {}
+func (*PluralNode) astNode()   {}
+func (*OrdinalNode) astNode()  {}
+func (*ChoiceNode) astNode()   {}
+func (*CustomNode) astNode()   {}
+
+// AST returns the AST of the parsed message.
+//
+// The AST is a copy: changing it does not change the way the message is formatted.
+func (x *MessageFormat) AST() *Message {
+	return x.root.toAST(x.source)
+}
+
+// Walk traverses an AST in depth-first order: it calls fn for the given node then, if fn returns true, walks
+// each of its children.
+func Walk(n Node, fn func(Node) bool) {
+	if n == nil || !fn(n) {
+		return
+	}
+
+	switch t := n.(type) {
+	case *Message:
+		for _, child := range t.Nodes {
+			Walk(child, fn)
+		}
+
+	case *SelectNode:
+		walkChoices(t.Choices, fn)
+
+	case *PluralNode:
+		walkChoices(t.Choices, fn)
+
+	case *OrdinalNode:
+		walkChoices(t.Choices, fn)
+
+	case *ChoiceNode:
+		for _, limit := range t.Limits {
+			Walk(limit.Message, fn)
+		}
+	}
+}
+
+func walkChoices(choices []*Choice, fn func(Node) bool) {
+	for _, choice := range choices {
+		Walk(choice.Message, fn)
+	}
+}
+
+func (x *node) toAST(source []rune) *Message {
+	result := &Message{Span: Span{x.start, x.end}}
+
+	for _, child := range x.children {
+		span := Span{child.start, child.end}
+
+		var n Node
+
+		switch child.ctype {
+		default:
+			// the name and the type can't hold a PartChar
+			parts := strings.SplitN(string(source[span.Start+1:span.End-1]), string(PartChar), 3)
+
+			style := ""
+			if len(parts) == 3 {
+				style = strings.TrimSpace(parts[2])
+			}
+			n = &CustomNode{span, strings.TrimSpace(parts[0]), child.ctype, style}
+
+		case "literal":
+			n = &LiteralNode{span, append([]string(nil), child.expr.([]string)...)}
+
+		case "var":
+			n = &ArgumentNode{span, child.expr.(string)}
+
+		case "select":
+			o := child.expr.(*selectExpr)
+			n = &SelectNode{span, o.key, choicesToAST(o.choices, source)}
+
+		case "selectordinal":
+			o := child.expr.(*selectExpr)
+			n = &OrdinalNode{span, o.key, choicesToAST(o.choices, source)}
+
+		case "plural":
+			o := child.expr.(*pluralExpr)
+			n = &PluralNode{span, o.key, o.offset, choicesToAST(o.choices, source)}
+
+		case "choice":
+			o := child.expr.(*choiceExpr)
+			limits := make([]*ChoiceLimit, len(o.limits))
+			for i, limit := range o.limits {
+				limits[i] = &ChoiceLimit{limit.value, limit.strict, limit.choice.toAST(source)}
+			}
+			n = &ChoiceNode{span, o.key, limits}
+		}
+
+		result.Nodes = append(result.Nodes, n)
+	}
+	return result
+}
+
+// choicesToAST returns the choices of a "select", "plural" or "selectordinal" expression, in the input order.
+func choicesToAST(choices map[string]*node, source []rune) []*Choice {
+	result := make([]*Choice, 0, len(choices))
+	for key, choice := range choices {
+		result = append(result, &Choice{key, choice.toAST(source)})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Message.Start < result[j].Message.Start
+	})
+	return result
+}
@@ -0,0 +1,120 @@
+package messageformat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// dumpAST describes each node visited by Walk as "Type[start:end] details".
+func dumpAST(root Node) []string {
+	var result []string
+
+	Walk(root, func(n Node) bool {
+		start, end := n.Offsets()
+		desc := fmt.Sprintf("%s[%d:%d]", reflect.TypeOf(n).Elem().Name(), start, end)
+
+		switch t := n.(type) {
+		case *LiteralNode:
+			desc += fmt.Sprintf(" %q", t.Value)
+
+		case *ArgumentNode:
+			desc += " " + t.Name
+
+		case *SelectNode:
+			desc += " " + t.Name + " " + choiceKeys(t.Choices)
+
+		case *PluralNode:
+			desc += fmt.Sprintf(" %s offset:%d %s", t.Name, t.Offset, choiceKeys(t.Choices))
+
+		case *OrdinalNode:
+			desc += " " + t.Name + " " + choiceKeys(t.Choices)
+
+		case *ChoiceNode:
+			desc += " " + t.Name
+			for _, limit := range t.Limits {
+				desc += fmt.Sprintf(" %v/%v", limit.Limit, limit.Strict)
+			}
+
+		case *CustomNode:
+			desc += fmt.Sprintf(" %s %s %q", t.Name, t.Type, t.Style)
+		}
+
+		result = append(result, desc)
+		return true
+	})
+	return result
+}
+
+func choiceKeys(choices []*Choice) string {
+	keys := make([]string, len(choices))
+	for i, choice := range choices {
+		keys[i] = choice.Key
+	}
+	return strings.Join(keys, ",")
+}
+
+func TestAST(t *testing.T) {
+	mf, err := doParse(`Hi {name}, {N, plural, offset:1 =0{none} other{# of {T, number, ::percent}}}{G, select, male{he} other{they}}{R, selectordinal, one{#st} other{#th}}{C, choice, 0#no|1<{C}}{D,date}`)
+	if err != nil {
+		t.Errorf("Unexpected parse failure: `%s`", err.Error())
+		return
+	}
+
+	expected := []string{
+		`Message[0:179]`,
+		`LiteralNode[0:3] ["Hi "]`,
+		`ArgumentNode[3:9] name`,
+		`LiteralNode[9:11] [", "]`,
+		`PluralNode[11:76] N offset:1 =0,other`,
+		`Message[35:39]`,
+		`LiteralNode[35:39] ["none"]`,
+		`Message[47:74]`,
+		`LiteralNode[47:52] ["" " of "]`,
+		`CustomNode[52:74] T number "::percent"`,
+		`SelectNode[76:109] G male,other`,
+		`Message[93:95]`,
+		`LiteralNode[93:95] ["he"]`,
+		`Message[103:107]`,
+		`LiteralNode[103:107] ["they"]`,
+		`OrdinalNode[109:148] R one,other`,
+		`Message[132:135]`,
+		`LiteralNode[132:135] ["" "st"]`,
+		`Message[143:146]`,
+		`LiteralNode[143:146] ["" "th"]`,
+		`ChoiceNode[148:171] C 0/false 1/true`,
+		`Message[162:164]`,
+		`LiteralNode[162:164] ["no"]`,
+		`Message[167:170]`,
+		`ArgumentNode[167:170] C`,
+		`CustomNode[171:179] D date ""`,
+	}
+
+	if result := dumpAST(mf.AST()); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Expecting <%v> but got <%v>", strings.Join(expected, "\n"), strings.Join(result, "\n"))
+	}
+
+	// fn returning false skips the children of a node
+	var names []string
+	Walk(mf.AST(), func(n Node) bool {
+		switch t := n.(type) {
+		case *ArgumentNode:
+			names = append(names, t.Name)
+
+		case *PluralNode:
+			return false
+		}
+		return true
+	})
+
+	if !reflect.DeepEqual(names, []string{"name", "C"}) {
+		t.Errorf("Expecting <[name C]> but got <%v>", names)
+	}
+
+	// the AST is a copy
+	mf.AST().Nodes[0].(*LiteralNode).Value[0] = "Bye "
+	if result, _ := mf.FormatMap(nil); !strings.HasPrefix(result, "Hi ") {
+		t.Errorf("Unexpected format result : `%s`", result)
+	}
+}
@@ -0,0 +1,188 @@
+package messageformat
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type (
+	choiceExpr struct {
+		key    string
+		limits []*choiceLimit
+	}
+
+	// A choiceLimit is one "limit#message" (or "limit<message") part of a choice expression.
+	choiceLimit struct {
+		value  float64
+		strict bool // true if the value must be greater than the limit ('<'), false if it can be equal ('#' or '≤')
+		choice *node
+	}
+)
+
+// parseChoice parses the legacy ICU choice format (i.e. "{N, choice, 0#no files|1#one file|1<{N} files}").
+func parseChoice(varname string, ptr_compiler *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	if char != PartChar {
+		return nil, start, ErrMalformedOption
+	}
+
+	result := new(choiceExpr)
+	result.key = varname
+
+	pos := start + 1
+
+	for pos < end {
+		limit, i, err := readChoiceLimit(pos, end, ptr_input)
+		if err != nil {
+			return nil, i, err
+		}
+
+		if n := len(result.limits); n != 0 {
+			previous := result.limits[n-1]
+			if limit.value < previous.value || (limit.value == previous.value && !limit.strict) {
+				return nil, pos, ErrUnorderedChoiceLimits
+			}
+		}
+
+		j, err := skipChoiceMessage(ptr_compiler, i+1, end, ptr_input)
+		if err != nil {
+			return nil, j, err
+		}
+
+		limit.choice = &node{parent: "choice"}
+		if k, _, err := ptr_compiler.parse(i+1, j, ptr_input, limit.choice); err != nil {
+			return nil, k, err
+		}
+
+		result.limits = append(result.limits, limit)
+		pos = j
+
+		if (*ptr_input)[pos] == CloseChar {
+			return result, pos, nil
+		}
+		pos++
+	}
+	return nil, pos, ErrUnbalancedBraces
+}
+
+// readChoiceLimit reads the limit of a choice part, up to its '#', '<' or '≤' separator.
+//
+// It returns the position of the separator.
+func readChoiceLimit(start, end int, ptr_input *[]rune) (*choiceLimit, int, error) {
+	input := *ptr_input
+
+	for pos := start; pos < end; pos++ {
+		switch input[pos] {
+		case PoundChar, '<', '≤':
+			s := strings.TrimSpace(string(input[start:pos]))
+			if s == "" {
+				return nil, pos, ErrMissingChoiceLimit
+			}
+
+			result := new(choiceLimit)
+			result.strict = input[pos] == '<'
+
+			switch s {
+			case "∞", "+∞":
+				result.value = math.Inf(1)
+
+			case "-∞":
+				result.value = math.Inf(-1)
+
+			default:
+				value, err := strconv.ParseFloat(s, 64)
+				if err != nil || math.IsNaN(value) {
+					return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidChoiceLimit, s)
+				}
+				result.value = value
+			}
+			return result, pos, nil
+
+		case OpenChar, CloseChar, '|':
+			return nil, pos, ErrMissingChoiceLimit
+		}
+	}
+	return nil, end, ErrUnbalancedBraces
+}
+
+// skipChoiceMessage traverses the message of a choice part, up to the '|' or '}' which ends it.
+func skipChoiceMessage(ptr_compiler *Parser, start, end int, ptr_input *[]rune) (int, error) {
+	input := *ptr_input
+	level := 0
+	escaped := false
+
+	for pos := start; pos < end; pos++ {
+		char := input[pos]
+
+		if escaped {
+			escaped = false
+			continue
+		}
+
+		switch char {
+		case EscapeChar:
+			escaped = ptr_compiler.apostrophe == EscapeBackslash
+
+		case QuoteChar:
+			pos = ptr_compiler.skipQuote(pos, end, ptr_input, "choice") - 1
+
+		case OpenChar:
+			level++
+
+		case CloseChar:
+			if level == 0 {
+				return pos, nil
+			}
+			level--
+
+		case '|':
+			if level == 0 {
+				return pos, nil
+			}
+		}
+	}
+	return end, ErrUnbalancedBraces
+}
+
+// formatChoice is the format function associated with the "choice" type.
+//
+// It will writes the message of the last limit the value reaches, or the first message if the value is below every limit.
+//
+// It will returns an error if the associated value can't be convert to a number (i.e. bool, ...)
+//
+// It will falls back to the first message if its key can't be found in the given map
+func formatChoice(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, pound string) error {
+	o := expr.(*choiceExpr)
+	selected := o.limits[0]
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	}
+
+	if ok && v != nil {
+		d, err := toDecimal(v)
+		if err != nil {
+			return err
+		}
+
+		value, err := strconv.ParseFloat(d.String(), 64)
+		if err != nil {
+			return err
+		}
+
+		for _, limit := range o.limits {
+			if value < limit.value || (limit.strict && value == limit.value) {
+				break
+			}
+			selected = limit
+		}
+	}
+
+	key := formatChoiceLimit(selected.value) + "#"
+	if selected.strict {
+		key = formatChoiceLimit(selected.value) + "<"
+	}
+	return withChoice(selected.choice.format(ptr_output, data, ptr_mf, pound), o.key, key)
+}
@@ -0,0 +1,63 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestChoice(t *testing.T) {
+	doTest(t, Test{
+		"There {N, choice, 0#are no files|1#is one file|1<are {N} files}.",
+		[]Expectation{
+			{map[string]interface{}{"N": 0}, "There are no files."},
+			{map[string]interface{}{"N": 1}, "There is one file."},
+			{map[string]interface{}{"N": 1.5}, "There are 1.5 files."},
+			{map[string]interface{}{"N": "12"}, "There are 12 files."},
+			{map[string]interface{}{"N": -3}, "There are no files."},
+			{nil, "There are no files."},
+		},
+	})
+
+	doTest(t, Test{
+		"{N,choice,-∞#negative|0≤zero|0<positive|∞#infinite}",
+		[]Expectation{
+			{map[string]interface{}{"N": -1}, "negative"},
+			{map[string]interface{}{"N": 0}, "zero"},
+			{map[string]interface{}{"N": 0.1}, "positive"},
+		},
+	})
+
+	doTest(t, Test{
+		`{N, choice, 0#{G, select, male{his|her} other{their}}|1#\{#\}}`,
+		[]Expectation{
+			{map[string]interface{}{"N": 0, "G": "male"}, "his|her"},
+			{map[string]interface{}{"N": 2}, "{#}"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, plural, other{{N, choice, 0##|1<many #}}}",
+		[]Expectation{
+			{map[string]interface{}{"N": 0}, "0"},
+			{map[string]interface{}{"N": 1200}, "many 1,200"},
+		},
+	})
+
+	doTestException(
+		t,
+		"{N, choice, 0#none|1#one}",
+		map[string]interface{}{"N": true},
+		"Number: Unsupported type: bool",
+	)
+}
+
+func TestChoiceParseException(t *testing.T) {
+	doTestParseException(t, "{N, choice}", "ParseError: `MalformedOption` at 10")
+	doTestParseException(t, "{N, choice, }", "ParseError: `MissingChoiceLimit` at 12")
+	doTestParseException(t, "{N, choice, #none}", "ParseError: `MissingChoiceLimit` at 12")
+	doTestParseException(t, "{N, choice, none}", "ParseError: `MissingChoiceLimit` at 16")
+	doTestParseException(t, "{N, choice, a#none}", "ParseError: `InvalidChoiceLimit: `a`` at 13")
+	doTestParseException(t, "{N, choice, 1#one|0#none}", "ParseError: `UnorderedChoiceLimits` at 18")
+	doTestParseException(t, "{N, choice, 1#one|1#again}", "ParseError: `UnorderedChoiceLimits` at 18")
+	doTestParseException(t, "{N, choice, 0#none|1#{N}", "ParseError: `UnbalancedBraces` at 24")
+	doTestParseException(t, "{N, choice, 0#{M, foo}}", "ParseError: `UnknownType: `foo`` at 21")
+}
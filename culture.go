@@ -0,0 +1,646 @@
+package messageformat
+
+import (
+	"github.com/gotnospirit/makeplural/plural"
+	"strings"
+)
+
+// cultureNames returns the names to look for in the locale data tables, from the most to the least specific.
+//
+// i.e. "pt-PT" gives ["pt-PT", "pt"]
+func cultureNames(name string) []string {
+	result := []string{name}
+	if i := strings.IndexAny(name, "-_"); i > 0 {
+		result = append(result, name[:i])
+	}
+	return result
+}
+
+// pluralFor returns the MessageFormat selecting the plural categories of the locale data found for the given
+// culture: itself if it is the data of its own culture, else a copy using the plural rules of that culture (i.e.
+// the "en" rules for the "en" data used as a fallback).
+func (x *MessageFormat) pluralFor(culture string) *MessageFormat {
+	for _, name := range cultureNames(x.culture) {
+		if name == culture {
+			return x
+		}
+	}
+
+	fn, err := plural.GetFunc(culture)
+	if err != nil {
+		return x
+	}
+
+	result := *x
+	result.plural = fn
+	return &result
+}
+
+// numberSymbols holds the locale data used to format numbers.
+type numberSymbols struct {
+	decimal     string            // decimal separator
+	group       string            // grouping separator
+	percent     string            // percent sign
+	permille    string            // per mille sign
+	minus       string            // minus sign
+	plus        string            // plus sign
+	exponent    string            // exponential symbol of the scientific notation
+	minGrouping int               // minimum number of digits in the leading group before the grouping separator is used
+	currency    string            // default ISO 4217 currency code
+	symbols     map[string]string // currency symbols, the ISO code is used when missing
+	formats     map[string]string // ICU patterns of the named styles
+	patterns    map[string]*numberPattern
+
+	// compact notation patterns by power of ten then plural category (i.e. 3: {"other": "0K"}),
+	// a pattern also applies to the two next powers when they are missing (i.e. "00K", "000K")
+	compactShort map[int]map[string]string
+	compactLong  map[int]map[string]string
+}
+
+// narrowCurrencySymbols lists the culture independent narrow symbols.
+var narrowCurrencySymbols = map[string]string{
+	"AUD": "$",
+	"CAD": "$",
+	"CNY": "¥",
+	"EUR": "€",
+	"GBP": "£",
+	"INR": "₹",
+	"JPY": "¥",
+	"USD": "$",
+}
+
+// currencyDigits lists the currencies which do not use 2 fraction digits.
+var currencyDigits = map[string]int{
+	"BHD": 3,
+	"CLP": 0,
+	"ISK": 0,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+	"VND": 0,
+}
+
+var numberData = map[string]*numberSymbols{
+	"en": {
+		decimal:     ".",
+		group:       ",",
+		percent:     "%",
+		permille:    "‰",
+		minus:       "-",
+		plus:        "+",
+		exponent:    "E",
+		minGrouping: 1,
+		currency:    "USD",
+		symbols:     map[string]string{"USD": "$", "EUR": "€", "GBP": "£", "JPY": "¥", "CAD": "CA$", "AUD": "A$", "CNY": "CN¥", "INR": "₹"},
+		formats: map[string]string{
+			"decimal":  "#,##0.###",
+			"integer":  "#,##0",
+			"percent":  "#,##0%",
+			"permille": "#,##0‰",
+			"currency": "¤#,##0.00",
+		},
+		compactShort: map[int]map[string]string{
+			3:  {"other": "0K"},
+			6:  {"other": "0M"},
+			9:  {"other": "0B"},
+			12: {"other": "0T"},
+		},
+		compactLong: map[int]map[string]string{
+			3:  {"other": "0 thousand"},
+			6:  {"other": "0 million"},
+			9:  {"other": "0 billion"},
+			12: {"other": "0 trillion"},
+		},
+	},
+	"fr": {
+		decimal:     ",",
+		group:       "\u202f",
+		percent:     "%",
+		permille:    "‰",
+		minus:       "-",
+		plus:        "+",
+		exponent:    "E",
+		minGrouping: 1,
+		currency:    "EUR",
+		symbols:     map[string]string{"USD": "$US", "EUR": "€", "GBP": "£GB", "JPY": "JPY", "CAD": "$CA", "AUD": "$AU", "CNY": "CNY", "INR": "₹"},
+		formats: map[string]string{
+			"decimal":  "#,##0.###",
+			"integer":  "#,##0",
+			"percent":  "#,##0\u202f%",
+			"permille": "#,##0\u202f‰",
+			"currency": "#,##0.00\u00a0¤",
+		},
+		compactShort: map[int]map[string]string{
+			3:  {"other": "0\u00a0k"},
+			6:  {"other": "0\u00a0M"},
+			9:  {"other": "0\u00a0Md"},
+			12: {"other": "0\u00a0Bn"},
+		},
+		compactLong: map[int]map[string]string{
+			3:  {"other": "0 mille"},
+			6:  {"one": "0 million", "other": "0 millions"},
+			9:  {"one": "0 milliard", "other": "0 milliards"},
+			12: {"one": "0 billion", "other": "0 billions"},
+		},
+	},
+	"de": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "%",
+		permille:    "‰",
+		minus:       "-",
+		plus:        "+",
+		exponent:    "E",
+		minGrouping: 1,
+		currency:    "EUR",
+		symbols:     map[string]string{"USD": "$", "EUR": "€", "GBP": "£", "JPY": "¥", "CAD": "CA$", "AUD": "AU$", "CNY": "CN¥", "INR": "₹"},
+		formats: map[string]string{
+			"decimal":  "#,##0.###",
+			"integer":  "#,##0",
+			"percent":  "#,##0\u00a0%",
+			"permille": "#,##0\u00a0‰",
+			"currency": "#,##0.00\u00a0¤",
+		},
+		compactShort: map[int]map[string]string{
+			6:  {"other": "0\u00a0Mio'.'"},
+			9:  {"other": "0\u00a0Mrd'.'"},
+			12: {"other": "0\u00a0Bio'.'"},
+		},
+		compactLong: map[int]map[string]string{
+			3:  {"other": "0 Tausend"},
+			6:  {"one": "0 Million", "other": "0 Millionen"},
+			9:  {"one": "0 Milliarde", "other": "0 Milliarden"},
+			12: {"one": "0 Billion", "other": "0 Billionen"},
+		},
+	},
+	"es": {
+		decimal:     ",",
+		group:       ".",
+		percent:     "%",
+		permille:    "‰",
+		minus:       "-",
+		plus:        "+",
+		exponent:    "E",
+		minGrouping: 2,
+		currency:    "EUR",
+		symbols:     map[string]string{"USD": "US$", "EUR": "€", "GBP": "GBP", "JPY": "JPY", "CAD": "CA$", "AUD": "AUD", "CNY": "CNY", "INR": "INR"},
+		formats: map[string]string{
+			"decimal":  "#,##0.###",
+			"integer":  "#,##0",
+			"percent":  "#,##0\u00a0%",
+			"permille": "#,##0\u00a0‰",
+			"currency": "#,##0.00\u00a0¤",
+		},
+		compactShort: map[int]map[string]string{
+			3:  {"other": "0\u00a0mil"},
+			6:  {"other": "0\u00a0M"},
+			9:  {"other": "0\u00a0mil\u00a0M"},
+			12: {"other": "0\u00a0B"},
+		},
+		compactLong: map[int]map[string]string{
+			3:  {"other": "0 mil"},
+			6:  {"one": "0 millón", "other": "0 millones"},
+			9:  {"other": "0 mil millones"},
+			12: {"one": "0 billón", "other": "0 billones"},
+		},
+	},
+}
+
+func init() {
+	for name, data := range numberData {
+		data.patterns = make(map[string]*numberPattern)
+		for style, format := range data.formats {
+			pattern, err := compileNumberPattern(format)
+			if err != nil {
+				panic("messageformat: invalid `" + style + "` pattern for `" + name + "`: " + err.Error())
+			}
+			data.patterns[style] = pattern
+		}
+	}
+}
+
+// getNumberSymbols returns the number symbols of the given culture, falling back to "en".
+func getNumberSymbols(culture string) *numberSymbols {
+	for _, name := range cultureNames(culture) {
+		if result, ok := numberData[name]; ok {
+			return result
+		}
+	}
+	return numberData["en"]
+}
+
+// currencySymbol returns the symbol used by the culture for the given ISO 4217 code.
+func (x *numberSymbols) currencySymbol(code string) string {
+	if result, ok := x.symbols[code]; ok {
+		return result
+	}
+	return code
+}
+
+// A listPattern holds the patterns used to join the items of a list, {0} and {1} being replaced by the items.
+type listPattern struct {
+	two    string // list of two items
+	start  string // first two items of a longer list
+	middle string
+	end    string // last two items of a longer list
+}
+
+// listData holds the list patterns by culture then style: "standard" (conjunction), "or" (disjunction) and "unit",
+// followed by "-short" or "-narrow" for the shorter widths.
+var listData = map[string]map[string]*listPattern{
+	"en": {
+		"standard":        {"{0} and {1}", "{0}, {1}", "{0}, {1}", "{0}, and {1}"},
+		"standard-short":  {"{0} & {1}", "{0}, {1}", "{0}, {1}", "{0}, & {1}"},
+		"standard-narrow": {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+		"or":              {"{0} or {1}", "{0}, {1}", "{0}, {1}", "{0}, or {1}"},
+		"or-short":        {"{0} or {1}", "{0}, {1}", "{0}, {1}", "{0}, or {1}"},
+		"or-narrow":       {"{0} or {1}", "{0}, {1}", "{0}, {1}", "{0}, or {1}"},
+		"unit":            {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+		"unit-short":      {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+		"unit-narrow":     {"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+	},
+	"fr": {
+		"standard":        {"{0} et {1}", "{0}, {1}", "{0}, {1}", "{0} et {1}"},
+		"standard-short":  {"{0} et {1}", "{0}, {1}", "{0}, {1}", "{0} et {1}"},
+		"standard-narrow": {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+		"or":              {"{0} ou {1}", "{0}, {1}", "{0}, {1}", "{0} ou {1}"},
+		"or-short":        {"{0} ou {1}", "{0}, {1}", "{0}, {1}", "{0} ou {1}"},
+		"or-narrow":       {"{0} ou {1}", "{0}, {1}", "{0}, {1}", "{0} ou {1}"},
+		"unit":            {"{0} et {1}", "{0}, {1}", "{0}, {1}", "{0} et {1}"},
+		"unit-short":      {"{0} et {1}", "{0}, {1}", "{0}, {1}", "{0} et {1}"},
+		"unit-narrow":     {"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+	},
+	"de": {
+		"standard":        {"{0} und {1}", "{0}, {1}", "{0}, {1}", "{0} und {1}"},
+		"standard-short":  {"{0} und {1}", "{0}, {1}", "{0}, {1}", "{0} und {1}"},
+		"standard-narrow": {"{0} und {1}", "{0}, {1}", "{0}, {1}", "{0} und {1}"},
+		"or":              {"{0} oder {1}", "{0}, {1}", "{0}, {1}", "{0} oder {1}"},
+		"or-short":        {"{0} oder {1}", "{0}, {1}", "{0}, {1}", "{0} oder {1}"},
+		"or-narrow":       {"{0} oder {1}", "{0}, {1}", "{0}, {1}", "{0} oder {1}"},
+		"unit":            {"{0} und {1}", "{0}, {1}", "{0}, {1}", "{0} und {1}"},
+		"unit-short":      {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+		"unit-narrow":     {"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+	},
+	"es": {
+		"standard":        {"{0} y {1}", "{0}, {1}", "{0}, {1}", "{0} y {1}"},
+		"standard-short":  {"{0} y {1}", "{0}, {1}", "{0}, {1}", "{0} y {1}"},
+		"standard-narrow": {"{0} y {1}", "{0}, {1}", "{0}, {1}", "{0} y {1}"},
+		"or":              {"{0} o {1}", "{0}, {1}", "{0}, {1}", "{0} o {1}"},
+		"or-short":        {"{0} o {1}", "{0}, {1}", "{0}, {1}", "{0} o {1}"},
+		"or-narrow":       {"{0} o {1}", "{0}, {1}", "{0}, {1}", "{0} o {1}"},
+		"unit":            {"{0} y {1}", "{0}, {1}", "{0}, {1}", "{0} y {1}"},
+		"unit-short":      {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+		"unit-narrow":     {"{0} {1}", "{0} {1}", "{0} {1}", "{0} {1}"},
+	},
+}
+
+// getListPattern returns the list pattern of the given culture and style, falling back to "en".
+func getListPattern(culture, style string) *listPattern {
+	for _, name := range cultureNames(culture) {
+		if result, ok := listData[name][style]; ok {
+			return result
+		}
+	}
+	return listData["en"][style]
+}
+
+// join returns the items joined by the patterns.
+func (x *listPattern) join(items []string) string {
+	n := len(items)
+
+	switch n {
+	case 0:
+		return ""
+
+	case 1:
+		return items[0]
+
+	case 2:
+		return replaceArgs(x.two, items[0], items[1])
+	}
+
+	result := replaceArgs(x.end, items[n-2], items[n-1])
+	for i := n - 3; i > 0; i-- {
+		result = replaceArgs(x.middle, items[i], result)
+	}
+	return replaceArgs(x.start, items[0], result)
+}
+
+// replaceArgs replaces the {0}, {1}, ... placeholders of a locale pattern by the given values.
+func replaceArgs(pattern string, values ...string) string {
+	var b strings.Builder
+
+	for pattern != "" {
+		i := strings.IndexByte(pattern, '{')
+		if i == -1 || i+2 >= len(pattern) || pattern[i+2] != '}' || pattern[i+1] < '0' || int(pattern[i+1]-'0') >= len(values) {
+			b.WriteString(pattern)
+			break
+		}
+
+		b.WriteString(pattern[:i])
+		b.WriteString(values[pattern[i+1]-'0'])
+		pattern = pattern[i+3:]
+	}
+	return b.String()
+}
+
+// formatCount formats an amount with the pattern of its plural category (see countPattern).
+func formatCount(patterns map[string]string, amount decimal, ptr_mf *MessageFormat) (string, error) {
+	pattern, err := countPattern(patterns, amount, ptr_mf)
+	if err != nil {
+		return "", err
+	}
+	return replaceArgs(pattern, formatDigits(amount, ptr_mf.culture)), nil
+}
+
+// countPattern returns the pattern of the plural category of an amount, falling back to the "other" one.
+func countPattern(patterns map[string]string, amount decimal, ptr_mf *MessageFormat) (string, error) {
+	if len(patterns) > 1 {
+		key, err := ptr_mf.getNamedKey(amount.String(), false)
+		if err != nil {
+			return "", err
+		}
+
+		if result, ok := patterns[key]; ok {
+			return result, nil
+		}
+	}
+	return patterns["other"], nil
+}
+
+// unitData holds the unit patterns by culture, then width ("long", "short", "narrow"), unit and plural category.
+var unitData = map[string]map[string]map[string]map[string]string{
+	"en": {
+		"long": {
+			"day":                {"one": "{0} day", "other": "{0} days"},
+			"hour":               {"one": "{0} hour", "other": "{0} hours"},
+			"minute":             {"one": "{0} minute", "other": "{0} minutes"},
+			"second":             {"one": "{0} second", "other": "{0} seconds"},
+			"millisecond":        {"one": "{0} millisecond", "other": "{0} milliseconds"},
+			"meter":              {"one": "{0} meter", "other": "{0} meters"},
+			"kilometer":          {"one": "{0} kilometer", "other": "{0} kilometers"},
+			"centimeter":         {"one": "{0} centimeter", "other": "{0} centimeters"},
+			"mile":               {"one": "{0} mile", "other": "{0} miles"},
+			"foot":               {"one": "{0} foot", "other": "{0} feet"},
+			"inch":               {"one": "{0} inch", "other": "{0} inches"},
+			"kilogram":           {"one": "{0} kilogram", "other": "{0} kilograms"},
+			"gram":               {"one": "{0} gram", "other": "{0} grams"},
+			"pound":              {"one": "{0} pound", "other": "{0} pounds"},
+			"liter":              {"one": "{0} liter", "other": "{0} liters"},
+			"kilometer-per-hour": {"one": "{0} kilometer per hour", "other": "{0} kilometers per hour"},
+			"mile-per-hour":      {"one": "{0} mile per hour", "other": "{0} miles per hour"},
+			"celsius":            {"one": "{0} degree Celsius", "other": "{0} degrees Celsius"},
+			"fahrenheit":         {"one": "{0} degree Fahrenheit", "other": "{0} degrees Fahrenheit"},
+		},
+		"short": {
+			"day":                {"one": "{0} day", "other": "{0} days"},
+			"hour":               {"other": "{0} hr"},
+			"minute":             {"other": "{0} min"},
+			"second":             {"other": "{0} sec"},
+			"millisecond":        {"other": "{0} ms"},
+			"meter":              {"other": "{0} m"},
+			"kilometer":          {"other": "{0} km"},
+			"centimeter":         {"other": "{0} cm"},
+			"mile":               {"other": "{0} mi"},
+			"foot":               {"other": "{0} ft"},
+			"inch":               {"other": "{0} in"},
+			"kilogram":           {"other": "{0} kg"},
+			"gram":               {"other": "{0} g"},
+			"pound":              {"other": "{0} lb"},
+			"liter":              {"other": "{0} L"},
+			"kilometer-per-hour": {"other": "{0} km/h"},
+			"mile-per-hour":      {"other": "{0} mph"},
+			"celsius":            {"other": "{0}°C"},
+			"fahrenheit":         {"other": "{0}°F"},
+		},
+		"narrow": {
+			"day":                {"other": "{0}d"},
+			"hour":               {"other": "{0}h"},
+			"minute":             {"other": "{0}m"},
+			"second":             {"other": "{0}s"},
+			"millisecond":        {"other": "{0}ms"},
+			"meter":              {"other": "{0}m"},
+			"kilometer":          {"other": "{0}km"},
+			"centimeter":         {"other": "{0}cm"},
+			"mile":               {"other": "{0}mi"},
+			"foot":               {"other": "{0}′"},
+			"inch":               {"other": "{0}″"},
+			"kilogram":           {"other": "{0}kg"},
+			"gram":               {"other": "{0}g"},
+			"pound":              {"other": "{0}lb"},
+			"liter":              {"other": "{0}L"},
+			"kilometer-per-hour": {"other": "{0}km/h"},
+			"mile-per-hour":      {"other": "{0}mph"},
+			"celsius":            {"other": "{0}°C"},
+			"fahrenheit":         {"other": "{0}°"},
+		},
+	},
+	"fr": {
+		"long": {
+			"day":                {"one": "{0} jour", "other": "{0} jours"},
+			"hour":               {"one": "{0} heure", "other": "{0} heures"},
+			"minute":             {"one": "{0} minute", "other": "{0} minutes"},
+			"second":             {"one": "{0} seconde", "other": "{0} secondes"},
+			"millisecond":        {"one": "{0} milliseconde", "other": "{0} millisecondes"},
+			"meter":              {"one": "{0} mètre", "other": "{0} mètres"},
+			"kilometer":          {"one": "{0} kilomètre", "other": "{0} kilomètres"},
+			"centimeter":         {"one": "{0} centimètre", "other": "{0} centimètres"},
+			"mile":               {"one": "{0} mile", "other": "{0} miles"},
+			"foot":               {"one": "{0} pied", "other": "{0} pieds"},
+			"inch":               {"one": "{0} pouce", "other": "{0} pouces"},
+			"kilogram":           {"one": "{0} kilogramme", "other": "{0} kilogrammes"},
+			"gram":               {"one": "{0} gramme", "other": "{0} grammes"},
+			"pound":              {"one": "{0} livre", "other": "{0} livres"},
+			"liter":              {"one": "{0} litre", "other": "{0} litres"},
+			"kilometer-per-hour": {"one": "{0} kilomètre par heure", "other": "{0} kilomètres par heure"},
+			"mile-per-hour":      {"one": "{0} mile par heure", "other": "{0} miles par heure"},
+			"celsius":            {"one": "{0} degré Celsius", "other": "{0} degrés Celsius"},
+			"fahrenheit":         {"one": "{0} degré Fahrenheit", "other": "{0} degrés Fahrenheit"},
+		},
+		"short": {
+			"day":                {"other": "{0}\u00a0j"},
+			"hour":               {"other": "{0}\u00a0h"},
+			"minute":             {"other": "{0}\u00a0min"},
+			"second":             {"other": "{0}\u00a0s"},
+			"millisecond":        {"other": "{0}\u00a0ms"},
+			"meter":              {"other": "{0}\u00a0m"},
+			"kilometer":          {"other": "{0}\u00a0km"},
+			"centimeter":         {"other": "{0}\u00a0cm"},
+			"mile":               {"other": "{0}\u00a0mi"},
+			"foot":               {"other": "{0}\u00a0pi"},
+			"inch":               {"other": "{0}\u00a0po"},
+			"kilogram":           {"other": "{0}\u00a0kg"},
+			"gram":               {"other": "{0}\u00a0g"},
+			"pound":              {"other": "{0}\u00a0lb"},
+			"liter":              {"other": "{0}\u00a0l"},
+			"kilometer-per-hour": {"other": "{0}\u00a0km/h"},
+			"mile-per-hour":      {"other": "{0}\u00a0mi/h"},
+			"celsius":            {"other": "{0}\u00a0°C"},
+			"fahrenheit":         {"other": "{0}\u00a0°F"},
+		},
+		"narrow": {
+			"day":                {"other": "{0}j"},
+			"hour":               {"other": "{0}h"},
+			"minute":             {"other": "{0}min"},
+			"second":             {"other": "{0}s"},
+			"millisecond":        {"other": "{0}ms"},
+			"meter":              {"other": "{0}m"},
+			"kilometer":          {"other": "{0}km"},
+			"centimeter":         {"other": "{0}cm"},
+			"mile":               {"other": "{0}mi"},
+			"foot":               {"other": "{0}′"},
+			"inch":               {"other": "{0}″"},
+			"kilogram":           {"other": "{0}kg"},
+			"gram":               {"other": "{0}g"},
+			"pound":              {"other": "{0}lb"},
+			"liter":              {"other": "{0}l"},
+			"kilometer-per-hour": {"other": "{0}km/h"},
+			"mile-per-hour":      {"other": "{0}mi/h"},
+			"celsius":            {"other": "{0}°C"},
+			"fahrenheit":         {"other": "{0}°F"},
+		},
+	},
+	"de": {
+		"long": {
+			"day":                {"one": "{0} Tag", "other": "{0} Tage"},
+			"hour":               {"one": "{0} Stunde", "other": "{0} Stunden"},
+			"minute":             {"one": "{0} Minute", "other": "{0} Minuten"},
+			"second":             {"one": "{0} Sekunde", "other": "{0} Sekunden"},
+			"millisecond":        {"one": "{0} Millisekunde", "other": "{0} Millisekunden"},
+			"meter":              {"other": "{0} Meter"},
+			"kilometer":          {"other": "{0} Kilometer"},
+			"centimeter":         {"other": "{0} Zentimeter"},
+			"mile":               {"one": "{0} Meile", "other": "{0} Meilen"},
+			"foot":               {"other": "{0} Fuß"},
+			"inch":               {"other": "{0} Zoll"},
+			"kilogram":           {"other": "{0} Kilogramm"},
+			"gram":               {"other": "{0} Gramm"},
+			"pound":              {"other": "{0} Pfund"},
+			"liter":              {"other": "{0} Liter"},
+			"kilometer-per-hour": {"other": "{0} Kilometer pro Stunde"},
+			"mile-per-hour":      {"one": "{0} Meile pro Stunde", "other": "{0} Meilen pro Stunde"},
+			"celsius":            {"other": "{0} Grad Celsius"},
+			"fahrenheit":         {"other": "{0} Grad Fahrenheit"},
+		},
+		"short": {
+			"day":                {"one": "{0} Tg.", "other": "{0} Tg."},
+			"hour":               {"other": "{0} Std."},
+			"minute":             {"other": "{0} Min."},
+			"second":             {"other": "{0} Sek."},
+			"millisecond":        {"other": "{0} ms"},
+			"meter":              {"other": "{0} m"},
+			"kilometer":          {"other": "{0} km"},
+			"centimeter":         {"other": "{0} cm"},
+			"mile":               {"other": "{0} mi"},
+			"foot":               {"other": "{0} ft"},
+			"inch":               {"other": "{0} in"},
+			"kilogram":           {"other": "{0} kg"},
+			"gram":               {"other": "{0} g"},
+			"pound":              {"other": "{0} lb"},
+			"liter":              {"other": "{0} l"},
+			"kilometer-per-hour": {"other": "{0} km/h"},
+			"mile-per-hour":      {"other": "{0} mi/h"},
+			"celsius":            {"other": "{0} °C"},
+			"fahrenheit":         {"other": "{0} °F"},
+		},
+		"narrow": {
+			"day":                {"other": "{0}T"},
+			"hour":               {"other": "{0}Std."},
+			"minute":             {"other": "{0}Min."},
+			"second":             {"other": "{0}s"},
+			"millisecond":        {"other": "{0}ms"},
+			"meter":              {"other": "{0}m"},
+			"kilometer":          {"other": "{0}km"},
+			"centimeter":         {"other": "{0}cm"},
+			"mile":               {"other": "{0}mi"},
+			"foot":               {"other": "{0}′"},
+			"inch":               {"other": "{0}″"},
+			"kilogram":           {"other": "{0}kg"},
+			"gram":               {"other": "{0}g"},
+			"pound":              {"other": "{0}lb"},
+			"liter":              {"other": "{0}l"},
+			"kilometer-per-hour": {"other": "{0}km/h"},
+			"mile-per-hour":      {"other": "{0}mi/h"},
+			"celsius":            {"other": "{0}°C"},
+			"fahrenheit":         {"other": "{0}°F"},
+		},
+	},
+	"es": {
+		"long": {
+			"day":                {"one": "{0} día", "other": "{0} días"},
+			"hour":               {"one": "{0} hora", "other": "{0} horas"},
+			"minute":             {"one": "{0} minuto", "other": "{0} minutos"},
+			"second":             {"one": "{0} segundo", "other": "{0} segundos"},
+			"millisecond":        {"one": "{0} milisegundo", "other": "{0} milisegundos"},
+			"meter":              {"one": "{0} metro", "other": "{0} metros"},
+			"kilometer":          {"one": "{0} kilómetro", "other": "{0} kilómetros"},
+			"centimeter":         {"one": "{0} centímetro", "other": "{0} centímetros"},
+			"mile":               {"one": "{0} milla", "other": "{0} millas"},
+			"foot":               {"one": "{0} pie", "other": "{0} pies"},
+			"inch":               {"one": "{0} pulgada", "other": "{0} pulgadas"},
+			"kilogram":           {"one": "{0} kilogramo", "other": "{0} kilogramos"},
+			"gram":               {"one": "{0} gramo", "other": "{0} gramos"},
+			"pound":              {"one": "{0} libra", "other": "{0} libras"},
+			"liter":              {"one": "{0} litro", "other": "{0} litros"},
+			"kilometer-per-hour": {"one": "{0} kilómetro por hora", "other": "{0} kilómetros por hora"},
+			"mile-per-hour":      {"one": "{0} milla por hora", "other": "{0} millas por hora"},
+			"celsius":            {"one": "{0} grado Celsius", "other": "{0} grados Celsius"},
+			"fahrenheit":         {"one": "{0} grado Fahrenheit", "other": "{0} grados Fahrenheit"},
+		},
+		"short": {
+			"day":                {"one": "{0} d", "other": "{0} d"},
+			"hour":               {"other": "{0} h"},
+			"minute":             {"other": "{0} min"},
+			"second":             {"other": "{0} s"},
+			"millisecond":        {"other": "{0} ms"},
+			"meter":              {"other": "{0} m"},
+			"kilometer":          {"other": "{0} km"},
+			"centimeter":         {"other": "{0} cm"},
+			"mile":               {"other": "{0} mi"},
+			"foot":               {"other": "{0} ft"},
+			"inch":               {"other": "{0} in"},
+			"kilogram":           {"other": "{0} kg"},
+			"gram":               {"other": "{0} g"},
+			"pound":              {"other": "{0} lb"},
+			"liter":              {"other": "{0} l"},
+			"kilometer-per-hour": {"other": "{0} km/h"},
+			"mile-per-hour":      {"other": "{0} mi/h"},
+			"celsius":            {"other": "{0} °C"},
+			"fahrenheit":         {"other": "{0} °F"},
+		},
+		"narrow": {
+			"day":                {"other": "{0}d"},
+			"hour":               {"other": "{0}h"},
+			"minute":             {"other": "{0}min"},
+			"second":             {"other": "{0}s"},
+			"millisecond":        {"other": "{0}ms"},
+			"meter":              {"other": "{0}m"},
+			"kilometer":          {"other": "{0}km"},
+			"centimeter":         {"other": "{0}cm"},
+			"mile":               {"other": "{0}mi"},
+			"foot":               {"other": "{0}′"},
+			"inch":               {"other": "{0}″"},
+			"kilogram":           {"other": "{0}kg"},
+			"gram":               {"other": "{0}g"},
+			"pound":              {"other": "{0}lb"},
+			"liter":              {"other": "{0}l"},
+			"kilometer-per-hour": {"other": "{0}km/h"},
+			"mile-per-hour":      {"other": "{0}mi/h"},
+			"celsius":            {"other": "{0}°C"},
+			"fahrenheit":         {"other": "{0}°F"},
+		},
+	},
+}
+
+// getUnitPatterns returns the patterns by plural category of a unit in the given culture, falling back to "en",
+// with the name of their culture.
+func getUnitPatterns(culture, width, unit string) (map[string]string, string) {
+	for _, name := range cultureNames(culture) {
+		if result, ok := unitData[name][width][unit]; ok {
+			return result, name
+		}
+	}
+	return unitData["en"][width][unit], "en"
+}
@@ -0,0 +1,574 @@
+package messageformat
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+type dateExpr struct {
+	key      string
+	time     bool        // true for the "time" type
+	style    string      // named style ("short", "medium", "long", "full"), custom pattern or skeleton
+	pattern  []dateField // compiled custom pattern, nil for a named style
+	skeleton string      // skeleton without its "::" prefix, resolved against the culture when formatting
+}
+
+// A dateField is either a pattern letter repeated count times (i.e. "MMM") or a literal text.
+type dateField struct {
+	symbol rune // 0 for a literal
+	count  int
+	text   string
+}
+
+// dateSymbols holds the locale data used to format dates and times.
+type dateSymbols struct {
+	months       [12]string
+	shortMonths  [12]string
+	days         [7]string // starting on Sunday
+	shortDays    [7]string
+	narrowDays   [7]string
+	periods      [2]string // AM, PM
+	eras         [2]string // BC, AD
+	longEras     [2]string
+	dateFormats  map[string]string
+	timeFormats  map[string]string
+	datePatterns map[string][]dateField
+	timePatterns map[string][]dateField
+
+	hour      rune              // preferred hour symbol ('h' or 'H'), used for the "j" skeleton symbol
+	glue      map[string]string // patterns combining a date {1} and a time {0} by date style
+	available map[string]string // patterns by skeleton, used to resolve the skeletons
+}
+
+var dateData = map[string]*dateSymbols{
+	"en": {
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		days:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortDays:   [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		narrowDays:  [7]string{"S", "M", "T", "W", "T", "F", "S"},
+		periods:     [2]string{"AM", "PM"},
+		eras:        [2]string{"BC", "AD"},
+		longEras:    [2]string{"Before Christ", "Anno Domini"},
+		dateFormats: map[string]string{
+			"full":   "EEEE, MMMM d, y",
+			"long":   "MMMM d, y",
+			"medium": "MMM d, y",
+			"short":  "M/d/yy",
+		},
+		timeFormats: map[string]string{
+			"full":   "h:mm:ss a zzzz",
+			"long":   "h:mm:ss a z",
+			"medium": "h:mm:ss a",
+			"short":  "h:mm a",
+		},
+		hour: 'h',
+		glue: map[string]string{
+			"full":   "{1} 'at' {0}",
+			"long":   "{1} 'at' {0}",
+			"medium": "{1}, {0}",
+			"short":  "{1}, {0}",
+		},
+		available: map[string]string{
+			"d": "d", "E": "EEE", "Ed": "d E", "Gy": "y G", "GyMMM": "MMM y G", "GyMMMd": "MMM d, y G", "GyMMMEd": "E, MMM d, y G",
+			"M": "L", "Md": "M/d", "MEd": "E, M/d", "MMM": "LLL", "MMMd": "MMM d", "MMMEd": "E, MMM d", "MMMMd": "MMMM d",
+			"y": "y", "yM": "M/y", "yMd": "M/d/y", "yMEd": "E, M/d/y", "yMMM": "MMM y", "yMMMd": "MMM d, y", "yMMMEd": "E, MMM d, y", "yMMMM": "MMMM y",
+			"h": "h a", "H": "HH", "hm": "h:mm a", "Hm": "HH:mm", "hms": "h:mm:ss a", "Hms": "HH:mm:ss", "ms": "mm:ss",
+			"Ehm": "E h:mm a", "EHm": "E HH:mm",
+		},
+	},
+	"fr": {
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		days:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		shortDays:   [7]string{"dim.", "lun.", "mar.", "mer.", "jeu.", "ven.", "sam."},
+		narrowDays:  [7]string{"D", "L", "M", "M", "J", "V", "S"},
+		periods:     [2]string{"AM", "PM"},
+		eras:        [2]string{"av. J.-C.", "ap. J.-C."},
+		longEras:    [2]string{"avant Jésus-Christ", "après Jésus-Christ"},
+		dateFormats: map[string]string{
+			"full":   "EEEE d MMMM y",
+			"long":   "d MMMM y",
+			"medium": "d MMM y",
+			"short":  "dd/MM/y",
+		},
+		timeFormats: map[string]string{
+			"full":   "HH:mm:ss zzzz",
+			"long":   "HH:mm:ss z",
+			"medium": "HH:mm:ss",
+			"short":  "HH:mm",
+		},
+		hour: 'H',
+		glue: map[string]string{
+			"full":   "{1} 'à' {0}",
+			"long":   "{1} 'à' {0}",
+			"medium": "{1} {0}",
+			"short":  "{1} {0}",
+		},
+		available: map[string]string{
+			"d": "d", "E": "E", "Ed": "E d", "Gy": "y G", "GyMMM": "MMM y G", "GyMMMd": "d MMM y G", "GyMMMEd": "E d MMM y G",
+			"M": "L", "Md": "dd/MM", "MEd": "E dd/MM", "MMM": "LLL", "MMMd": "d MMM", "MMMEd": "E d MMM", "MMMMd": "d MMMM",
+			"y": "y", "yM": "MM/y", "yMd": "dd/MM/y", "yMEd": "E dd/MM/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "E d MMM y", "yMMMM": "MMMM y",
+			"h": "h a", "H": "HH 'h'", "hm": "h:mm a", "Hm": "HH:mm", "hms": "h:mm:ss a", "Hms": "HH:mm:ss", "ms": "mm:ss",
+			"Ehm": "E h:mm a", "EHm": "E HH:mm",
+		},
+	},
+	"de": {
+		months:      [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		shortMonths: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni", "Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		days:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		shortDays:   [7]string{"So.", "Mo.", "Di.", "Mi.", "Do.", "Fr.", "Sa."},
+		narrowDays:  [7]string{"S", "M", "D", "M", "D", "F", "S"},
+		periods:     [2]string{"AM", "PM"},
+		eras:        [2]string{"v. Chr.", "n. Chr."},
+		longEras:    [2]string{"v. Chr.", "n. Chr."},
+		dateFormats: map[string]string{
+			"full":   "EEEE, d. MMMM y",
+			"long":   "d. MMMM y",
+			"medium": "dd.MM.y",
+			"short":  "dd.MM.yy",
+		},
+		timeFormats: map[string]string{
+			"full":   "HH:mm:ss zzzz",
+			"long":   "HH:mm:ss z",
+			"medium": "HH:mm:ss",
+			"short":  "HH:mm",
+		},
+		hour: 'H',
+		glue: map[string]string{
+			"full":   "{1} 'um' {0}",
+			"long":   "{1} 'um' {0}",
+			"medium": "{1}, {0}",
+			"short":  "{1}, {0}",
+		},
+		available: map[string]string{
+			"d": "d", "E": "EEE", "Ed": "E, d.", "Gy": "y G", "GyMMM": "MMM y G", "GyMMMd": "d. MMM y G", "GyMMMEd": "E, d. MMM y G",
+			"M": "L", "Md": "d.M.", "MEd": "E, d.M.", "MMM": "LLL", "MMMd": "d. MMM", "MMMEd": "E, d. MMM", "MMMMd": "d. MMMM",
+			"y": "y", "yM": "M/y", "yMd": "d.M.y", "yMEd": "E, d.M.y", "yMMM": "MMM y", "yMMMd": "d. MMM y", "yMMMEd": "E, d. MMM y", "yMMMM": "MMMM y",
+			"h": "h 'Uhr' a", "H": "HH 'Uhr'", "hm": "h:mm a", "Hm": "HH:mm", "hms": "h:mm:ss a", "Hms": "HH:mm:ss", "ms": "mm:ss",
+			"Ehm": "E h:mm a", "EHm": "E, HH:mm",
+		},
+	},
+	"es": {
+		months:      [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		shortMonths: [12]string{"ene", "feb", "mar", "abr", "may", "jun", "jul", "ago", "sept", "oct", "nov", "dic"},
+		days:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		shortDays:   [7]string{"dom", "lun", "mar", "mié", "jue", "vie", "sáb"},
+		narrowDays:  [7]string{"D", "L", "M", "X", "J", "V", "S"},
+		periods:     [2]string{"a. m.", "p. m."},
+		eras:        [2]string{"a. C.", "d. C."},
+		longEras:    [2]string{"antes de Cristo", "después de Cristo"},
+		dateFormats: map[string]string{
+			"full":   "EEEE, d 'de' MMMM 'de' y",
+			"long":   "d 'de' MMMM 'de' y",
+			"medium": "d MMM y",
+			"short":  "d/M/yy",
+		},
+		timeFormats: map[string]string{
+			"full":   "H:mm:ss (zzzz)",
+			"long":   "H:mm:ss z",
+			"medium": "H:mm:ss",
+			"short":  "H:mm",
+		},
+		hour: 'H',
+		glue: map[string]string{
+			"full":   "{1}, {0}",
+			"long":   "{1}, {0}",
+			"medium": "{1}, {0}",
+			"short":  "{1}, {0}",
+		},
+		available: map[string]string{
+			"d": "d", "E": "EEE", "Ed": "E d", "Gy": "y G", "GyMMM": "MMM y G", "GyMMMd": "d MMM y G", "GyMMMEd": "E, d MMM y G",
+			"M": "L", "Md": "d/M", "MEd": "E, d/M", "MMM": "LLL", "MMMd": "d MMM", "MMMEd": "E, d MMM", "MMMMd": "d 'de' MMMM",
+			"y": "y", "yM": "M/y", "yMd": "d/M/y", "yMEd": "EEE, d/M/y", "yMMM": "MMM y", "yMMMd": "d MMM y", "yMMMEd": "EEE, d MMM y", "yMMMM": "MMMM 'de' y",
+			"h": "h a", "H": "H", "hm": "h:mm a", "Hm": "H:mm", "hms": "h:mm:ss a", "Hms": "H:mm:ss", "ms": "mm:ss",
+			"Ehm": "E, h:mm a", "EHm": "E, H:mm",
+		},
+	},
+}
+
+func init() {
+	compile := func(name string, formats map[string]string) map[string][]dateField {
+		result := make(map[string][]dateField)
+		for style, format := range formats {
+			pattern, err := compileDatePattern(format)
+			if err != nil {
+				panic("messageformat: invalid `" + style + "` date pattern for `" + name + "`: " + err.Error())
+			}
+			result[style] = pattern
+		}
+		return result
+	}
+
+	for name, data := range dateData {
+		data.datePatterns = compile(name, data.dateFormats)
+		data.timePatterns = compile(name, data.timeFormats)
+	}
+}
+
+// getDateSymbols returns the date symbols of the given culture, falling back to "en".
+func getDateSymbols(culture string) *dateSymbols {
+	for _, name := range cultureNames(culture) {
+		if result, ok := dateData[name]; ok {
+			return result
+		}
+	}
+	return dateData["en"]
+}
+
+func parseDate(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	return parseDateTime(varname, false, char, start, end, ptr_input)
+}
+
+func parseTime(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	return parseDateTime(varname, true, char, start, end, ptr_input)
+}
+
+func parseDateTime(varname string, isTime bool, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	result := new(dateExpr)
+	result.key = varname
+	result.time = isTime
+	result.style = "medium"
+
+	if char == CloseChar {
+		return result, start, nil
+	}
+
+	style, pos, err := readStyle(start+1, end, ptr_input)
+	if err != nil {
+		return nil, pos, err
+	}
+
+	switch style {
+	case "":
+		return nil, pos, ErrMissingStyle
+
+	case "short", "medium", "long", "full":
+		result.style = style
+
+	default:
+		if strings.HasPrefix(style, "::") {
+			skeleton := strings.TrimSpace(style[2:])
+			if err := validateDateSkeleton(skeleton); err != nil {
+				return nil, pos, err
+			}
+			result.style = style
+			result.skeleton = skeleton
+			break
+		}
+
+		pattern, err := compileDatePattern(style)
+		if err != nil {
+			return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+		}
+		result.style = style
+		result.pattern = pattern
+	}
+	return result, pos, nil
+}
+
+// formatDate is the format function associated with the "date" and "time" types.
+//
+// It will returns an error if :
+// - the associated value is not a time.Time or a Unix timestamp (in seconds)
+//
+// It will writes nothing if its key can't be found in the given map
+func formatDate(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+	o := expr.(*dateExpr)
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	} else if !ok || v == nil {
+		return nil
+	}
+
+	value, err := toTime(v)
+	if err != nil {
+		return err
+	}
+
+	if ptr_mf.location != nil {
+		value = value.In(ptr_mf.location)
+	}
+
+	symbols := getDateSymbols(ptr_mf.culture)
+
+	pattern := o.pattern
+	if o.skeleton != "" {
+		pattern = symbols.resolveSkeleton(o.skeleton)
+	} else if pattern == nil {
+		if o.time {
+			pattern = symbols.timePatterns[o.style]
+		} else {
+			pattern = symbols.datePatterns[o.style]
+		}
+	}
+
+	writeDate(ptr_output, value, pattern, symbols)
+	return nil
+}
+
+// toTime tries to convert a value into a time.Time.
+//
+// Unix timestamps (in seconds) are converted into UTC times.
+func toTime(value interface{}) (time.Time, error) {
+	switch t := value.(type) {
+	case time.Time:
+		return t, nil
+
+	case *time.Time:
+		if t != nil {
+			return *t, nil
+		}
+
+	case int:
+		return time.Unix(int64(t), 0).UTC(), nil
+
+	case int32:
+		return time.Unix(int64(t), 0).UTC(), nil
+
+	case int64:
+		return time.Unix(t, 0).UTC(), nil
+
+	case uint32:
+		return time.Unix(int64(t), 0).UTC(), nil
+
+	case float64:
+		sec, frac := math.Modf(t)
+		return time.Unix(int64(sec), int64(frac*1e9)).UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("Date: %w: %T", ErrUnsupportedType, value)
+}
+
+// compileDatePattern parses an ICU date pattern (i.e. "EEE, MMM d 'at' HH:mm").
+func compileDatePattern(input string) ([]dateField, error) {
+	var result []dateField
+	var literal bytes.Buffer
+
+	runes := []rune(input)
+	quoted := false
+
+	flush := func() {
+		if literal.Len() != 0 {
+			result = append(result, dateField{text: literal.String()})
+			literal.Reset()
+		}
+	}
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		switch {
+		case c == '\'':
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				literal.WriteRune(c)
+				i++
+			} else {
+				quoted = !quoted
+			}
+
+		case quoted || !isDateLetter(c):
+			literal.WriteRune(c)
+
+		default:
+			if strings.IndexRune("GyMLdDEahHKkmsSzZXx", c) == -1 {
+				return nil, fmt.Errorf("UnsupportedField: `%c`", c)
+			}
+
+			count := 1
+			for i+1 < len(runes) && runes[i+1] == c {
+				count++
+				i++
+			}
+
+			flush()
+			result = append(result, dateField{symbol: c, count: count})
+		}
+	}
+
+	if quoted {
+		return nil, errors.New("UnbalancedQuotes")
+	}
+
+	flush()
+	return result, nil
+}
+
+// isDateLetter returns true if the rune is reserved as a pattern letter.
+func isDateLetter(c rune) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// writeDate writes the time using the given pattern and symbols.
+func writeDate(ptr_output Writer, value time.Time, pattern []dateField, symbols *dateSymbols) {
+	for _, field := range pattern {
+		n := field.count
+
+		switch field.symbol {
+		case 0:
+			ptr_output.WriteString(field.text)
+
+		case 'G':
+			era := 1
+			if value.Year() <= 0 {
+				era = 0
+			}
+			if n == 4 {
+				ptr_output.WriteString(symbols.longEras[era])
+			} else {
+				ptr_output.WriteString(symbols.eras[era])
+			}
+
+		case 'y':
+			year := value.Year()
+			if year <= 0 {
+				year = 1 - year
+			}
+			if n == 2 {
+				writeDigits(ptr_output, year%100, 2)
+			} else {
+				writeDigits(ptr_output, year, n)
+			}
+
+		case 'M', 'L':
+			month := int(value.Month()) - 1
+			switch {
+			case n <= 2:
+				writeDigits(ptr_output, month+1, n)
+
+			case n == 3:
+				ptr_output.WriteString(symbols.shortMonths[month])
+
+			case n == 4:
+				ptr_output.WriteString(symbols.months[month])
+
+			default:
+				r, _ := utf8.DecodeRuneInString(symbols.months[month])
+				ptr_output.WriteString(strings.ToUpper(string(r)))
+			}
+
+		case 'd':
+			writeDigits(ptr_output, value.Day(), n)
+
+		case 'D':
+			writeDigits(ptr_output, value.YearDay(), n)
+
+		case 'E':
+			day := int(value.Weekday())
+			switch {
+			case n == 4:
+				ptr_output.WriteString(symbols.days[day])
+
+			case n == 5:
+				ptr_output.WriteString(symbols.narrowDays[day])
+
+			default:
+				ptr_output.WriteString(symbols.shortDays[day])
+			}
+
+		case 'a':
+			ptr_output.WriteString(symbols.periods[value.Hour()/12])
+
+		case 'h':
+			hour := value.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			writeDigits(ptr_output, hour, n)
+
+		case 'H':
+			writeDigits(ptr_output, value.Hour(), n)
+
+		case 'K':
+			writeDigits(ptr_output, value.Hour()%12, n)
+
+		case 'k':
+			hour := value.Hour()
+			if hour == 0 {
+				hour = 24
+			}
+			writeDigits(ptr_output, hour, n)
+
+		case 'm':
+			writeDigits(ptr_output, value.Minute(), n)
+
+		case 's':
+			writeDigits(ptr_output, value.Second(), n)
+
+		case 'S':
+			digits := fmt.Sprintf("%09d", value.Nanosecond())
+			for len(digits) < n {
+				digits += "0"
+			}
+			ptr_output.WriteString(digits[:n])
+
+		case 'z':
+			if n < 4 {
+				name, _ := value.Zone()
+				ptr_output.WriteString(name)
+			} else {
+				writeZone(ptr_output, value, "GMT", true, false)
+			}
+
+		case 'Z':
+			switch {
+			case n < 4:
+				writeZone(ptr_output, value, "", false, false)
+
+			case n == 4:
+				writeZone(ptr_output, value, "GMT", true, false)
+
+			default:
+				writeZone(ptr_output, value, "", true, true)
+			}
+
+		case 'X', 'x':
+			writeZone(ptr_output, value, "", n >= 3, field.symbol == 'X')
+		}
+	}
+}
+
+// writeDigits writes a positive integer, left padded with zeros up to the given width.
+func writeDigits(ptr_output Writer, value, width int) {
+	s := strconv.Itoa(value)
+	for i := len(s); i < width; i++ {
+		ptr_output.WriteByte('0')
+	}
+	ptr_output.WriteString(s)
+}
+
+// writeZone writes the offset of the time zone (i.e. "GMT+02:00", "+0200", "Z").
+func writeZone(ptr_output Writer, value time.Time, prefix string, colon, utc bool) {
+	_, offset := value.Zone()
+
+	if offset == 0 {
+		if prefix != "" {
+			ptr_output.WriteString(prefix)
+			return
+		} else if utc {
+			ptr_output.WriteByte('Z')
+			return
+		}
+	}
+
+	ptr_output.WriteString(prefix)
+	if offset < 0 {
+		ptr_output.WriteByte('-')
+		offset = -offset
+	} else {
+		ptr_output.WriteByte('+')
+	}
+
+	writeDigits(ptr_output, offset/3600, 2)
+	if colon {
+		ptr_output.WriteByte(':')
+	}
+	writeDigits(ptr_output, offset%3600/60, 2)
+}
@@ -0,0 +1,248 @@
+package messageformat
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// skeletonSymbols lists the symbols accepted in a date skeleton.
+const skeletonSymbols = "GyMLdEcahHKkjJCmsSzZvOXx"
+
+// validateDateSkeleton checks that a date skeleton (i.e. "yMMMdjm") only contains known symbols.
+func validateDateSkeleton(skeleton string) error {
+	if skeleton == "" {
+		return fmt.Errorf("%w: `%s`", ErrInvalidSkeleton, skeleton)
+	}
+
+	for _, c := range skeleton {
+		if strings.IndexRune(skeletonSymbols, c) == -1 {
+			return fmt.Errorf("%w: `%s`", ErrInvalidSkeleton, skeleton)
+		}
+	}
+	return nil
+}
+
+// resolveSkeleton returns the pattern of the culture which best matches the given date skeleton.
+//
+// The date and time parts of the skeleton are matched separately against the available formats of the culture,
+// then combined using the culture's glue pattern.
+func (x *dateSymbols) resolveSkeleton(skeleton string) []dateField {
+	var date, clock []dateField
+	var zone, fraction int
+
+	zoneSymbol := 'z'
+	for _, field := range readSkeleton(skeleton, x.hour) {
+		switch field.symbol {
+		case 'G', 'y', 'M', 'd', 'E':
+			date = append(date, field)
+
+		case 'h', 'H', 'k', 'K', 'm', 's':
+			clock = append(clock, field)
+
+		case 'S':
+			fraction = field.count
+
+		case 'z', 'Z', 'X', 'x':
+			zoneSymbol, zone = field.symbol, field.count
+		}
+	}
+
+	datePattern := x.matchSkeleton(date)
+	timePattern := x.matchSkeleton(clock)
+
+	if fraction != 0 {
+		for i, field := range timePattern {
+			if field.symbol == 's' {
+				timePattern = append(timePattern[:i+1], append([]dateField{{text: "."}, {symbol: 'S', count: fraction}}, timePattern[i+1:]...)...)
+				break
+			}
+		}
+	}
+
+	if zone != 0 {
+		if len(timePattern) != 0 {
+			timePattern = append(timePattern, dateField{text: " "})
+		}
+		timePattern = append(timePattern, dateField{symbol: zoneSymbol, count: zone})
+	}
+
+	if len(datePattern) == 0 {
+		return timePattern
+	} else if len(timePattern) == 0 {
+		return datePattern
+	}
+
+	style := "short"
+	for _, field := range date {
+		if field.symbol == 'M' && field.count >= 4 {
+			style = "long"
+			for _, field := range date {
+				if field.symbol == 'E' {
+					style = "full"
+				}
+			}
+		} else if field.symbol == 'M' && field.count == 3 {
+			style = "medium"
+		}
+	}
+
+	glue, _ := compileDatePattern(x.glue[style])
+
+	var result []dateField
+	for _, field := range glue {
+		switch {
+		case field.symbol == 0 && field.text == "{1}":
+			result = append(result, datePattern...)
+
+		case field.symbol == 0 && field.text == "{0}":
+			result = append(result, timePattern...)
+
+		case field.symbol == 0 && strings.Contains(field.text, "{"):
+			text := field.text
+			for text != "" {
+				i := strings.IndexByte(text, '{')
+				if i == -1 || i+2 >= len(text) {
+					result = append(result, dateField{text: text})
+					break
+				}
+
+				result = append(result, dateField{text: text[:i]})
+				if text[i+1] == '1' {
+					result = append(result, datePattern...)
+				} else {
+					result = append(result, timePattern...)
+				}
+				text = text[i+3:]
+			}
+
+		default:
+			result = append(result, field)
+		}
+	}
+	return result
+}
+
+// readSkeleton splits a skeleton into its fields, normalizing the symbols which are aliases of another one.
+func readSkeleton(skeleton string, hour rune) []dateField {
+	var result []dateField
+
+	runes := []rune(skeleton)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		count := 1
+		for i+1 < len(runes) && runes[i+1] == c {
+			count++
+			i++
+		}
+
+		switch c {
+		case 'a':
+			// the day period comes along with the hour symbol of the pattern
+			continue
+
+		case 'L':
+			c = 'M'
+
+		case 'c':
+			c = 'E'
+
+		case 'j', 'J', 'C':
+			c = hour
+
+		case 'v':
+			c = 'z'
+
+		case 'O':
+			c, count = 'Z', 4
+		}
+		result = append(result, dateField{symbol: c, count: count})
+	}
+	return result
+}
+
+// matchSkeleton returns the available pattern best matching the requested fields, adjusted to their widths.
+//
+// When no available pattern has the same set of fields, the fields are simply joined by a space.
+func (x *dateSymbols) matchSkeleton(requested []dateField) []dateField {
+	if len(requested) == 0 {
+		return nil
+	}
+
+	counts := make(map[rune]int)
+	for _, field := range requested {
+		counts[field.symbol] = field.count
+	}
+
+	var candidates []string
+	for skeleton := range x.available {
+		candidates = append(candidates, skeleton)
+	}
+	sort.Strings(candidates)
+
+	best, distance := "", -1
+	for _, skeleton := range candidates {
+		fields := readSkeleton(skeleton, x.hour)
+		if len(fields) != len(counts) {
+			continue
+		}
+
+		d := 0
+		for _, field := range fields {
+			count, ok := counts[field.symbol]
+			if !ok {
+				d = -1
+				break
+			} else if field.symbol == 'M' && (count >= 3) != (field.count >= 3) {
+				d += 0x100
+			}
+
+			if count > field.count {
+				d += count - field.count
+			} else {
+				d += field.count - count
+			}
+		}
+
+		if d != -1 && (distance == -1 || d < distance) {
+			best, distance = skeleton, d
+		}
+	}
+
+	var result []dateField
+	if best == "" {
+		for i, field := range requested {
+			if i > 0 {
+				result = append(result, dateField{text: " "})
+			}
+			result = append(result, field)
+		}
+		return result
+	}
+
+	pattern, _ := compileDatePattern(x.available[best])
+	for _, field := range pattern {
+		symbol := field.symbol
+		if symbol == 'L' {
+			symbol = 'M'
+		}
+
+		if count, ok := counts[symbol]; ok {
+			switch {
+			case symbol == 'y':
+				if count == 2 {
+					field.count = 2
+				}
+
+			case count >= 3 && field.count >= 3:
+				field.count = count
+
+			case count > field.count:
+				field.count = count
+			}
+		}
+		result = append(result, field)
+	}
+	return result
+}
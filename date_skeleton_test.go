@@ -0,0 +1,48 @@
+package messageformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateSkeleton(t *testing.T) {
+	when := time.Date(2015, time.March, 7, 16, 5, 9, 123456789, time.UTC)
+
+	doTest(t, Test{
+		"{D, date, ::yMMMd}|{D, date, ::yMMMdjm}|{D, date, ::yMd}|{D, date, ::MMMMd}|{D, date, ::yMMMMEEEEd}",
+		[]Expectation{
+			{map[string]interface{}{"D": when}, "Mar 7, 2015|Mar 7, 2015, 4:05 PM|3/7/2015|March 7|Saturday, March 7, 2015"},
+			{nil, "||||"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, time, ::jm}|{D, time, ::Hms}|{D, time, ::hmsSSS}|{D, time, ::jmz}|{D, time, ::ms}",
+		[]Expectation{
+			{map[string]interface{}{"D": when}, "4:05 PM|16:05:09|4:05:09.123 PM|4:05 PM UTC|05:09"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, date, ::yMMMMdjm}|{D, date, ::yyMMdd}|{D, date, ::GyMMMEd}",
+		[]Expectation{
+			{map[string]interface{}{"D": when}, "March 7, 2015 at 4:05 PM|03/07/15|Sat, Mar 7, 2015 AD"},
+		},
+	})
+}
+
+func TestDateSkeletonCulture(t *testing.T) {
+	when := time.Date(2015, time.March, 7, 16, 5, 9, 0, time.UTC)
+
+	doTestWithCulture(t, "fr", Test{
+		"{D, date, ::yMMMdjm}|{D, date, ::yMd}|{D, time, ::jm}|{D, date, ::yMMMMEEEEd}",
+		[]Expectation{
+			{map[string]interface{}{"D": when}, "7 mars 2015 16:05|07/03/2015|16:05|samedi 7 mars 2015"},
+		},
+	})
+}
+
+func TestDateSkeletonParseException(t *testing.T) {
+	doTestParseException(t, "{D, date, ::}", "ParseError: `InvalidSkeleton: ``` at 12")
+	doTestParseException(t, "{D, date, ::yMMMq}", "ParseError: `InvalidSkeleton: `yMMMq`` at 17")
+}
@@ -0,0 +1,120 @@
+package messageformat
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDate(t *testing.T) {
+	when := time.Date(2015, time.March, 7, 16, 5, 9, 123456789, time.UTC)
+
+	doTest(t, Test{
+		"Last login {WHEN, date, long} at {WHEN, time, short}",
+		[]Expectation{
+			{map[string]interface{}{"WHEN": when}, "Last login March 7, 2015 at 4:05 PM"},
+			{map[string]interface{}{"WHEN": when.Unix()}, "Last login March 7, 2015 at 4:05 PM"},
+			{map[string]interface{}{"WHEN": int(when.Unix())}, "Last login March 7, 2015 at 4:05 PM"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, date}|{D, date, short}|{D, date, medium}|{D, date, long}|{D, date, full}",
+		[]Expectation{
+			{map[string]interface{}{"D": when}, "Mar 7, 2015|3/7/15|Mar 7, 2015|March 7, 2015|Saturday, March 7, 2015"},
+			{nil, "||||"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, time}|{D, time, short}|{D, time, medium}|{D, time, long}|{D, time, full}",
+		[]Expectation{
+			{map[string]interface{}{"D": &when}, "4:05:09 PM|4:05 PM|4:05:09 PM|4:05:09 PM UTC|4:05:09 PM GMT"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, date, yyyy-MM-dd'T'HH:mm:ss.SSSXXX}|{D, date, EEEEE MMMMM G GGGG D}|{D, time, K k 'o''clock' Z}",
+		[]Expectation{
+			{map[string]interface{}{"D": when}, "2015-03-07T16:05:09.123Z|S M AD Anno Domini 66|4 16 o'clock +0000"},
+		},
+	})
+
+	doTestException(
+		t,
+		"{D, date}",
+		map[string]interface{}{"D": "2015-03-07"},
+		"Date: Unsupported type: string",
+	)
+}
+
+func TestDateCulture(t *testing.T) {
+	when := time.Date(2015, time.March, 7, 16, 5, 9, 0, time.UTC)
+	data := map[string]interface{}{"D": when}
+
+	doTestWithCulture(t, "fr", Test{
+		"{D, date, full} à {D, time, short}",
+		[]Expectation{
+			{data, "samedi 7 mars 2015 à 16:05"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{D, date, medium} {D, date, long}",
+		[]Expectation{
+			{data, "07.03.2015 7. März 2015"},
+		},
+	})
+
+	doTestWithCulture(t, "es", Test{
+		"{D, date, long}, {D, time, short}",
+		[]Expectation{
+			{data, "7 de marzo de 2015, 16:05"},
+		},
+	})
+}
+
+func TestDateTimeZone(t *testing.T) {
+	mf, err := doParse("{D, date, short} {D, time, long}")
+	if err != nil {
+		t.Errorf("Unexpected parse failure: `%s`", err.Error())
+		return
+	}
+
+	data := map[string]interface{}{"D": time.Date(2015, time.March, 7, 23, 30, 0, 0, time.UTC)}
+	loc := time.FixedZone("JST", 9*3600)
+
+	result, err := mf.FormatMap(data, WithTimeZone(loc))
+	if err != nil {
+		t.Errorf("Unexpected error : `%s`", err.Error())
+	} else if expected := "3/8/15 8:30:00 AM JST"; result != expected {
+		t.Errorf("Expecting <%s> but got <%s>", expected, result)
+	} else if testing.Verbose() {
+		fmt.Printf("- Got expected value <%s>\n", result)
+	}
+
+	// the option only applies to a single call
+	result, err = mf.FormatMap(data)
+	if err != nil {
+		t.Errorf("Unexpected error : `%s`", err.Error())
+	} else if expected := "3/7/15 11:30:00 PM UTC"; result != expected {
+		t.Errorf("Expecting <%s> but got <%s>", expected, result)
+	} else if testing.Verbose() {
+		fmt.Printf("- Got expected value <%s>\n", result)
+	}
+}
+
+func TestDateParseException(t *testing.T) {
+	doTestParseException(t, "{D, date, }", "ParseError: `MissingStyle` at 10")
+	doTestParseException(t, "{D, time, HH:mm:ss 'o''clock}", "ParseError: `UnbalancedBraces` at 29")
+	doTestParseException(t, "{D, date, YYYY}", "ParseError: `InvalidStyle: `YYYY`` at 14")
+}
+
+func BenchmarkDate(b *testing.B) {
+	doBenchmarkExecute(
+		b,
+		"This is a {A, date, medium}",
+		"This is a Mar 7, 2015",
+		map[string]interface{}{"A": time.Date(2015, time.March, 7, 16, 5, 9, 0, time.UTC)},
+	)
+}
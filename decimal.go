@@ -0,0 +1,355 @@
+package messageformat
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// A roundingMode tells how a decimal is rounded when digits are dropped.
+type roundingMode int
+
+const (
+	roundHalfEven roundingMode = iota
+	roundHalfUp
+	roundHalfDown
+	roundCeiling
+	roundFloor
+	roundDown // towards zero
+	roundUp   // away from zero
+)
+
+// A decimal is an arbitrary precision, base 10, representation of a number.
+// It is used by the number formatters so that no precision is lost between
+// the input value and the rendered digits.
+type decimal struct {
+	neg      bool
+	integer  string // integer digits, without leading zeros (empty for 0)
+	fraction string // fraction digits
+}
+
+// maxExponent bounds the exponent of a numeric string, so that its expansion stays small.
+const maxExponent = 400
+
+// parseDecimal converts a numeric string (i.e. "-1234.50", "1e3") into a decimal.
+//
+// A string whose exponent is beyond maxExponent is rejected.
+func parseDecimal(input string) (decimal, bool) {
+	var result decimal
+
+	s := input
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		result.neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	exp := 0
+	if i := strings.IndexAny(s, "eE"); i != -1 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e > maxExponent || e < -maxExponent {
+			return result, false
+		}
+		exp = e
+		s = s[:i]
+	}
+
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+
+	if integer == "" && fraction == "" {
+		return result, false
+	}
+
+	for _, digits := range []string{integer, fraction} {
+		for i := 0; i < len(digits); i++ {
+			if digits[i] < '0' || digits[i] > '9' {
+				return result, false
+			}
+		}
+	}
+
+	result.integer = strings.TrimLeft(integer, "0")
+	result.fraction = fraction
+	result.shift(exp)
+	return result, true
+}
+
+// toDecimal tries to convert a value into a decimal.
+//
+// It will returns an error if the value's type is not <string/numeric> or if the string is not a number.
+func toDecimal(value interface{}) (decimal, error) {
+	var s string
+
+	switch t := value.(type) {
+	default:
+		return decimal{}, fmt.Errorf("Number: %w: %T", ErrUnsupportedType, value)
+
+	case string:
+		s = t
+
+	case int:
+		s = strconv.Itoa(t)
+
+	case int8:
+		s = strconv.FormatInt(int64(t), 10)
+
+	case int16:
+		s = strconv.FormatInt(int64(t), 10)
+
+	case int32:
+		s = strconv.FormatInt(int64(t), 10)
+
+	case int64:
+		s = strconv.FormatInt(t, 10)
+
+	case uint:
+		s = strconv.FormatUint(uint64(t), 10)
+
+	case uint8:
+		s = strconv.FormatUint(uint64(t), 10)
+
+	case uint16:
+		s = strconv.FormatUint(uint64(t), 10)
+
+	case uint32:
+		s = strconv.FormatUint(uint64(t), 10)
+
+	case uint64:
+		s = strconv.FormatUint(t, 10)
+
+	case float32:
+		if math.IsNaN(float64(t)) || math.IsInf(float64(t), 0) {
+			return decimal{}, fmt.Errorf("Number: %w: `%v`", ErrInvalidValue, t)
+		}
+		s = strconv.FormatFloat(float64(t), 'f', -1, 32)
+
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return decimal{}, fmt.Errorf("Number: %w: `%v`", ErrInvalidValue, t)
+		}
+		s = strconv.FormatFloat(t, 'f', -1, 64)
+	}
+
+	result, ok := parseDecimal(s)
+	if !ok {
+		return result, fmt.Errorf("Number: %w: `%s`", ErrInvalidValue, s)
+	}
+	return result, nil
+}
+
+// String returns the plain form of the decimal (i.e. "-1234.5").
+func (x *decimal) String() string {
+	result := x.integer
+	if result == "" {
+		result = "0"
+	}
+	if x.fraction != "" {
+		result += "." + x.fraction
+	}
+	if x.neg {
+		result = "-" + result
+	}
+	return result
+}
+
+// isZero returns true if every digit of the decimal is a zero.
+func (x *decimal) isZero() bool {
+	return strings.Trim(x.integer, "0") == "" && strings.Trim(x.fraction, "0") == ""
+}
+
+// shift multiplies the decimal by 10^n.
+func (x *decimal) shift(n int) {
+	switch {
+	case n > 0:
+		if n > len(x.fraction) {
+			x.fraction += strings.Repeat("0", n-len(x.fraction))
+		}
+		x.integer = strings.TrimLeft(x.integer+x.fraction[:n], "0")
+		x.fraction = x.fraction[n:]
+
+	case n < 0:
+		n = -n
+		if n > len(x.integer) {
+			x.integer = strings.Repeat("0", n-len(x.integer)) + x.integer
+		}
+		i := len(x.integer) - n
+		x.fraction = x.integer[i:] + x.fraction
+		x.integer = strings.TrimLeft(x.integer[:i], "0")
+	}
+}
+
+// magnitude returns the power of ten of the most significant digit (i.e. 2 for 123.4, -2 for 0.012).
+func (x *decimal) magnitude() int {
+	if x.integer != "" {
+		return len(x.integer) - 1
+	} else if i := strings.IndexFunc(x.fraction, func(c rune) bool { return c != '0' }); i != -1 {
+		return -i - 1
+	}
+	return 0
+}
+
+// multiply multiplies the decimal by another one.
+func (x *decimal) multiply(y decimal) {
+	a, _ := new(big.Int).SetString("0"+x.integer+x.fraction, 10)
+	b, _ := new(big.Int).SetString("0"+y.integer+y.fraction, 10)
+
+	places := len(x.fraction) + len(y.fraction)
+	digits := a.Mul(a, b).String()
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	x.neg = x.neg != y.neg
+	x.integer = strings.TrimLeft(digits[:len(digits)-places], "0")
+	x.fraction = digits[len(digits)-places:]
+}
+
+// round rounds the decimal to the given number of fraction digits.
+func (x *decimal) round(places int, mode roundingMode) {
+	if places >= len(x.fraction) {
+		return
+	}
+
+	digits := []byte(x.integer + x.fraction)
+	point := len(x.integer)
+	cut := point + places
+
+	if cut < 0 {
+		digits = append([]byte(strings.Repeat("0", -cut)), digits...)
+		point -= cut
+		cut = 0
+	}
+
+	kept, dropped := digits[:cut], digits[cut:]
+
+	var last byte = '0'
+	if cut > 0 {
+		last = kept[cut-1]
+	}
+
+	if mustIncrement(last, dropped, x.neg, mode) {
+		i := len(kept) - 1
+		for ; i >= 0; i-- {
+			if kept[i] != '9' {
+				kept[i]++
+				break
+			}
+			kept[i] = '0'
+		}
+
+		if i < 0 {
+			kept = append([]byte{'1'}, kept...)
+			point++
+		}
+	}
+
+	if places >= 0 {
+		x.integer, x.fraction = string(kept[:point]), string(kept[point:])
+	} else {
+		x.integer, x.fraction = string(kept)+strings.Repeat("0", -places), ""
+	}
+	x.integer = strings.TrimLeft(x.integer, "0")
+}
+
+// trim removes the trailing zeros of the fraction, keeping at least minFrac digits.
+func (x *decimal) trim(minFrac int) {
+	fraction := strings.TrimRight(x.fraction, "0")
+	if len(fraction) < minFrac {
+		fraction += strings.Repeat("0", minFrac-len(fraction))
+	}
+	x.fraction = fraction
+}
+
+// roundIncrement rounds the decimal to the nearest multiple of the given (positive) increment.
+func (x *decimal) roundIncrement(increment decimal, mode roundingMode) {
+	places := len(x.fraction)
+	if len(increment.fraction) > places {
+		places = len(increment.fraction)
+	}
+
+	value, _ := new(big.Int).SetString("0"+x.integer+x.fraction+strings.Repeat("0", places-len(x.fraction)), 10)
+	step, _ := new(big.Int).SetString("0"+increment.integer+increment.fraction+strings.Repeat("0", places-len(increment.fraction)), 10)
+	if step.Sign() == 0 {
+		return
+	}
+
+	q, r := new(big.Int).QuoRem(value, step, new(big.Int))
+	if r.Sign() != 0 {
+		half := new(big.Int).Lsh(r, 1).Cmp(step)
+		if shouldRoundUp(mode, x.neg, half, q.Bit(0) == 1) {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+
+	digits := q.Mul(q, step).String()
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+
+	x.integer = strings.TrimLeft(digits[:len(digits)-places], "0")
+	x.fraction = digits[len(digits)-places:]
+}
+
+// mustIncrement returns true if the dropped digits require to increment the last kept digit.
+func mustIncrement(last byte, dropped []byte, neg bool, mode roundingMode) bool {
+	half := 0
+	switch {
+	case dropped[0] > '5':
+		half = 1
+
+	case dropped[0] < '5':
+		half = -1
+	}
+
+	zero := dropped[0] == '0'
+	for _, c := range dropped[1:] {
+		if c != '0' {
+			zero = false
+			if half == 0 {
+				half = 1
+			}
+			break
+		}
+	}
+
+	if zero {
+		return false
+	}
+	return shouldRoundUp(mode, neg, half, (last-'0')%2 == 1)
+}
+
+// shouldRoundUp applies the rounding mode to a non-exact value.
+//
+// half is negative, zero or positive when the dropped part is below, equal to or above the half of a unit.
+func shouldRoundUp(mode roundingMode, neg bool, half int, odd bool) bool {
+	switch mode {
+	case roundCeiling:
+		return !neg
+
+	case roundFloor:
+		return neg
+
+	case roundDown:
+		return false
+
+	case roundUp:
+		return true
+	}
+
+	if half != 0 {
+		return half > 0
+	}
+
+	switch mode {
+	case roundHalfUp:
+		return true
+
+	case roundHalfDown:
+		return false
+	}
+	return odd
+}
@@ -0,0 +1,53 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func decimalResult(t *testing.T, input string, places int, expected string) {
+	value, ok := parseDecimal(input)
+	if !ok {
+		t.Errorf("Expecting `%s` to be parsed", input)
+		return
+	}
+
+	value.round(places, roundHalfEven)
+
+	result := value.String()
+
+	if expected != result {
+		t.Errorf("Expecting `%s` but got `%s`", expected, result)
+	} else if testing.Verbose() {
+		fmt.Printf("Successfully returns the expected value: `%s`\n", expected)
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	for _, input := range []string{"", "-", ".", "1.2.3", "1,2", "abc", "1e", "0x10", "1e401", "1e-401", "1e999999999"} {
+		if _, ok := parseDecimal(input); ok {
+			t.Errorf("Expecting `%s` to be rejected", input)
+		}
+	}
+
+	decimalResult(t, "00012.500", 3, "12.500")
+	decimalResult(t, "-1.5e2", 3, "-150")
+	decimalResult(t, "1.5e-2", 3, "0.015")
+	decimalResult(t, ".5", 3, "0.5")
+	decimalResult(t, "1e400", 0, "1"+strings.Repeat("0", 400))
+}
+
+func TestDecimalRound(t *testing.T) {
+	// half-even
+	decimalResult(t, "2.5", 0, "2")
+	decimalResult(t, "3.5", 0, "4")
+	decimalResult(t, "2.51", 0, "3")
+	decimalResult(t, "0.125", 2, "0.12")
+	decimalResult(t, "9.999", 2, "10.00")
+	decimalResult(t, "1234", -2, "1200")
+	decimalResult(t, "1250", -2, "1200")
+	decimalResult(t, "1350", -2, "1400")
+	decimalResult(t, "49", -3, "0")
+	decimalResult(t, "951", -3, "1000")
+}
@@ -0,0 +1,215 @@
+package messageformat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type durationExpr struct {
+	key      string
+	style    string // "numeric", "narrow", "short" or "long"
+	largest  int    // index in durationUnits of the largest unit to use
+	smallest int    // index in durationUnits of the smallest unit to use
+}
+
+// durationUnits lists the units of a duration, from the largest to the smallest.
+var durationUnits = []struct {
+	name string
+	size time.Duration
+}{
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+	{"millisecond", time.Millisecond},
+}
+
+func parseDuration(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	result := new(durationExpr)
+	result.key = varname
+	result.style = "numeric"
+	result.largest = -1
+	result.smallest = -1
+
+	pos := start
+	if char != CloseChar {
+		style, i, err := readStyle(start+1, end, ptr_input)
+		if err != nil {
+			return nil, i, err
+		} else if style == "" {
+			return nil, i, ErrMissingStyle
+		}
+
+		pos = i
+		err = result.setStyle(style)
+		if err != nil {
+			return nil, pos, err
+		}
+	}
+
+	if result.largest == -1 {
+		result.largest = 0
+		if result.style == "numeric" {
+			result.largest = 1
+		}
+	}
+
+	if result.smallest == -1 {
+		result.smallest = 3
+	}
+
+	if result.largest > result.smallest {
+		return nil, pos, ErrInvalidUnitRange
+	}
+	return result, pos, nil
+}
+
+// setStyle interprets the space separated tokens of a duration style (i.e. "short largest-hour smallest-minute").
+func (x *durationExpr) setStyle(style string) error {
+	for _, token := range strings.Fields(style) {
+		switch {
+		case token == "numeric", token == "narrow", token == "short", token == "long":
+			x.style = token
+
+		case token == "%in-numerals":
+			x.style = "numeric"
+
+		case token == "%with-words":
+			x.style = "long"
+
+		case strings.HasPrefix(token, "largest-"):
+			x.largest = durationUnitIndex(token[len("largest-"):])
+			if x.largest == -1 {
+				return fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+			}
+
+		case strings.HasPrefix(token, "smallest-"):
+			x.smallest = durationUnitIndex(token[len("smallest-"):])
+			if x.smallest == -1 {
+				return fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+			}
+
+		default:
+			return fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+		}
+	}
+	return nil
+}
+
+func durationUnitIndex(name string) int {
+	for i, unit := range durationUnits {
+		if unit.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatDuration is the format function associated with the "duration" type.
+//
+// It will returns an error if :
+// - the associated value is not a time.Duration or a number of seconds
+// - the pluralFunc is not defined (MessageFormat.getNamedKey) and a width other than "numeric" is used
+//
+// It will writes nothing if its key can't be found in the given map
+func formatDuration(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+	o := expr.(*durationExpr)
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	} else if !ok || v == nil {
+		return nil
+	}
+
+	value, err := toDuration(v)
+	if err != nil {
+		return err
+	}
+
+	if value < 0 {
+		ptr_output.WriteString(getNumberSymbols(ptr_mf.culture).minus)
+		value = -value
+	}
+
+	// amount of each unit, from the largest to the smallest
+	amounts := make([]int64, o.smallest-o.largest+1)
+	for i := range amounts {
+		size := durationUnits[o.largest+i].size
+		amounts[i] = int64(value / size)
+		value %= size
+	}
+
+	if o.style == "numeric" {
+		for i, amount := range amounts {
+			switch {
+			case i == 0:
+				ptr_output.WriteString(strconv.FormatInt(amount, 10))
+
+			case durationUnits[o.largest+i].name == "millisecond":
+				fmt.Fprintf(ptr_output, "%s%03d", getNumberSymbols(ptr_mf.culture).decimal, amount)
+
+			default:
+				fmt.Fprintf(ptr_output, ":%02d", amount)
+			}
+		}
+		return nil
+	}
+
+	var parts []string
+	for i, amount := range amounts {
+		if amount == 0 && !(len(parts) == 0 && i == len(amounts)-1) {
+			continue
+		}
+
+		unit := durationUnits[o.largest+i].name
+		part, err := formatUnitAmount(integerDecimal(amount), o.style, unit, ptr_mf)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, part)
+	}
+
+	style := "unit"
+	if o.style != "long" {
+		style += "-" + o.style
+	}
+	ptr_output.WriteString(getListPattern(ptr_mf.culture, style).join(parts))
+	return nil
+}
+
+// formatUnitAmount formats an amount of a unit, choosing the unit pattern with the plural function.
+func formatUnitAmount(amount decimal, width, unit string, ptr_mf *MessageFormat) (string, error) {
+	patterns, culture := getUnitPatterns(ptr_mf.culture, width, unit)
+	if patterns == nil {
+		return "", fmt.Errorf("%w: `%s`", ErrUnknownUnit, unit)
+	}
+	return formatCount(patterns, amount, ptr_mf.pluralFor(culture))
+}
+
+// toDuration tries to convert a value into a time.Duration.
+//
+// Numbers are considered as an amount of seconds.
+func toDuration(value interface{}) (time.Duration, error) {
+	if result, ok := value.(time.Duration); ok {
+		return result, nil
+	}
+
+	seconds, err := toDecimal(value)
+	if err != nil {
+		return 0, err
+	}
+
+	seconds.shift(9)
+	seconds.round(0, roundDown)
+
+	result, err := strconv.ParseInt("0"+seconds.integer, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Duration: %w: `%v`", ErrOutOfRange, value)
+	} else if seconds.neg {
+		result = -result
+	}
+	return time.Duration(result), nil
+}
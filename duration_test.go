@@ -0,0 +1,64 @@
+package messageformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	d := 2*time.Hour + 5*time.Minute + 30*time.Second
+
+	doTest(t, Test{
+		"{D, duration}|{D, duration, long}|{D, duration, short}|{D, duration, narrow}",
+		[]Expectation{
+			{map[string]interface{}{"D": d}, "2:05:30|2 hours, 5 minutes, 30 seconds|2 hr, 5 min, 30 sec|2h 5m 30s"},
+			{map[string]interface{}{"D": 3930}, "1:05:30|1 hour, 5 minutes, 30 seconds|1 hr, 5 min, 30 sec|1h 5m 30s"},
+			{map[string]interface{}{"D": "61.5"}, "0:01:01|1 minute, 1 second|1 min, 1 sec|1m 1s"},
+			{map[string]interface{}{"D": 0}, "0:00:00|0 seconds|0 sec|0s"},
+			{map[string]interface{}{"D": -90}, "-0:01:30|-1 minute, 30 seconds|-1 min, 30 sec|-1m 30s"},
+			{map[string]interface{}{"D": 200000}, "55:33:20|2 days, 7 hours, 33 minutes, 20 seconds|2 days, 7 hr, 33 min, 20 sec|2d 7h 33m 20s"},
+			{nil, "|||"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, duration, long smallest-minute}|{D, duration, narrow largest-minute}|{D, duration, largest-minute smallest-millisecond}|{D, duration, %with-words largest-hour smallest-hour}",
+		[]Expectation{
+			{map[string]interface{}{"D": d + 250*time.Millisecond}, "2 hours, 5 minutes|125m 30s|125:30.250|2 hours"},
+			{map[string]interface{}{"D": 1500 * time.Hour}, "62 days, 12 hours|90,000m|90000:00.000|1,500 hours"},
+		},
+	})
+
+	doTestException(
+		t,
+		"{D, duration}",
+		map[string]interface{}{"D": true},
+		"Number: Unsupported type: bool",
+	)
+}
+
+func TestDurationCulture(t *testing.T) {
+	d := 2*time.Hour + 1*time.Minute
+
+	doTestWithCulture(t, "fr", Test{
+		"{D, duration, long}|{D, duration, short}|{D, duration, narrow}",
+		[]Expectation{
+			{map[string]interface{}{"D": d}, "2 heures et 1 minute|2\u00a0h et 1\u00a0min|2h 1min"},
+			{map[string]interface{}{"D": d + 3*time.Second}, "2 heures, 1 minute et 3 secondes|2\u00a0h, 1\u00a0min et 3\u00a0s|2h 1min 3s"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{D, duration, long}",
+		[]Expectation{
+			{map[string]interface{}{"D": d}, "2 Stunden und 1 Minute"},
+		},
+	})
+}
+
+func TestDurationParseException(t *testing.T) {
+	doTestParseException(t, "{D, duration, }", "ParseError: `MissingStyle` at 14")
+	doTestParseException(t, "{D, duration, medium}", "ParseError: `InvalidStyle: `medium`` at 20")
+	doTestParseException(t, "{D, duration, largest-week}", "ParseError: `InvalidStyle: `largest-week`` at 26")
+	doTestParseException(t, "{D, duration, largest-second smallest-hour}", "ParseError: `InvalidUnitRange` at 42")
+}
@@ -1,15 +1,198 @@
 package messageformat
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
-// A parseError is used to embed an error message occurring while the processing an input.
-type parseError struct {
-	msg string // description of the error
-	pos int    // offset read
+// The codes of the errors occurring while parsing a message.
+//
+// A ParseError matches its code with errors.Is (i.e. errors.Is(err, ErrUnknownType)).
+var (
+	ErrUnbalancedBraces       = errors.New("UnbalancedBraces")
+	ErrInvalidExpr            = errors.New("InvalidExpr")
+	ErrInvalidFormat          = errors.New("InvalidFormat")
+	ErrMissingVarName         = errors.New("MissingVarName")
+	ErrUnknownType            = errors.New("UnknownType")
+	ErrUndefinedParseFunc     = errors.New("UndefinedParseFunc")
+	ErrMalformedOption        = errors.New("MalformedOption")
+	ErrMissingChoiceName      = errors.New("MissingChoiceName")
+	ErrMissingChoiceContent   = errors.New("MissingChoiceContent")
+	ErrMissingMandatoryChoice = errors.New("MissingMandatoryChoice")
+	ErrUnexpectedExtension    = errors.New("UnexpectedExtension")
+	ErrUnsupportedExtension   = errors.New("UnsupportedExtension")
+	ErrMissingOffsetValue     = errors.New("MissingOffsetValue")
+	ErrInvalidOffsetValue     = errors.New("InvalidOffsetValue")
+	ErrBadCast                = errors.New("BadCast")
+	ErrMissingStyle           = errors.New("MissingStyle")
+	ErrInvalidStyle           = errors.New("InvalidStyle")
+	ErrInvalidSkeleton        = errors.New("InvalidSkeleton")
+	ErrMissingUnit            = errors.New("MissingUnit")
+	ErrInvalidUnitRange       = errors.New("InvalidUnitRange")
+	ErrMissingChoiceLimit     = errors.New("MissingChoiceLimit")
+	ErrInvalidChoiceLimit     = errors.New("InvalidChoiceLimit")
+	ErrUnorderedChoiceLimits  = errors.New("UnorderedChoiceLimits")
+)
+
+var parseErrorCodes = []error{
+	ErrUnbalancedBraces, ErrInvalidExpr, ErrInvalidFormat, ErrMissingVarName, ErrUnknownType,
+	ErrUndefinedParseFunc, ErrMalformedOption, ErrMissingChoiceName, ErrMissingChoiceContent,
+	ErrMissingMandatoryChoice, ErrUnexpectedExtension, ErrUnsupportedExtension, ErrMissingOffsetValue,
+	ErrInvalidOffsetValue, ErrBadCast, ErrMissingStyle, ErrInvalidStyle, ErrInvalidSkeleton, ErrMissingUnit,
+	ErrInvalidUnitRange, ErrMissingChoiceLimit, ErrInvalidChoiceLimit, ErrUnorderedChoiceLimits,
+}
+
+// A ParseError is used to embed an error message occurring while the processing an input.
+type ParseError struct {
+	Code     error  // one of the Err* codes, nil for an error of a custom parse function
+	Err      error  // description of the error
+	Offset   int    // offset read, in runes
+	Line     int    // line of the offset, starting at 1
+	Column   int    // column of the offset, in runes, starting at 1
+	Argument string // name of the innermost argument being parsed, if any
+
+	source []rune
+}
+
+// argumentError keeps the name of the argument in which an error occurred while it goes up the parse functions.
+type argumentError struct {
+	name string
+	err  error
+}
+
+func (x argumentError) Error() string {
+	return x.err.Error()
+}
+
+func (x argumentError) Unwrap() error {
+	return x.err
+}
+
+func newParseError(err error, offset int, source []rune) *ParseError {
+	result := &ParseError{Err: err, Offset: offset, Line: 1, Column: 1, source: source}
+
+	var argument argumentError
+	if errors.As(err, &argument) {
+		result.Err = argument.err
+		result.Argument = argument.name
+	}
+
+	for _, code := range parseErrorCodes {
+		if errors.Is(err, code) {
+			result.Code = code
+			break
+		}
+	}
+
+	if offset > len(source) {
+		offset = len(source)
+	}
+
+	for _, c := range source[:offset] {
+		if c == '\n' {
+			result.Line++
+			result.Column = 1
+		} else {
+			result.Column++
+		}
+	}
+	return result
+}
+
+func (x *ParseError) Error() string {
+	return fmt.Sprintf("ParseError: `%s` at %d", x.Err, x.Offset)
+}
+
+func (x *ParseError) Unwrap() error {
+	return x.Err
+}
+
+// Snippet returns the line of the input where the error occurred, followed by a line with a caret under the
+// offending char (i.e. for CI logs).
+func (x *ParseError) Snippet() string {
+	offset := x.Offset
+	if offset > len(x.source) {
+		offset = len(x.source)
+	}
+	start := offset - (x.Column - 1)
+
+	end := start
+	for end < len(x.source) && x.source[end] != '\n' {
+		end++
+	}
+
+	// tabs are kept so that the caret stays aligned
+	margin := []rune(strings.Repeat(" ", x.Column-1))
+	for i, c := range x.source[start:offset] {
+		if c == '\t' {
+			margin[i] = '\t'
+		}
+	}
+	return strings.TrimSuffix(string(x.source[start:end]), "\r") + "\n" + string(margin) + "^"
+}
+
+// The codes of the errors occurring while formatting a message.
+//
+// A FormatError matches its code with errors.Is (i.e. errors.Is(err, ErrUnsupportedType)).
+var (
+	ErrUnsupportedType     = errors.New("Unsupported type")
+	ErrInvalidValue        = errors.New("Invalid value")
+	ErrOutOfRange          = errors.New("Out of range")
+	ErrUnknownSegment      = errors.New("Unknown segment")
+	ErrUnknownRuleSet      = errors.New("UnknownRuleSet")
+	ErrUnknownUnit         = errors.New("UnknownUnit")
+	ErrUndefinedPluralFunc = errors.New("UndefinedPluralFunc")
+	ErrUndefinedFormatFunc = errors.New("UndefinedFormatFunc")
+	ErrMissingArgument     = errors.New("MissingArgument")
+)
+
+var formatErrorCodes = []error{
+	ErrUnsupportedType, ErrInvalidValue, ErrOutOfRange, ErrUnknownSegment, ErrUnknownRuleSet, ErrUnknownUnit,
+	ErrUndefinedPluralFunc, ErrUndefinedFormatFunc, ErrUnknownType, ErrMissingArgument,
+}
+
+// A FormatError is used to embed an error message occurring while formatting a message.
+type FormatError struct {
+	Code     error          // one of the Err* codes, nil for an error of a custom format function
+	Err      error          // description of the error
+	Argument string         // name of the argument being formatted
+	Path     []FormatChoice // choices selected to reach the argument, the outermost first
+}
+
+// A FormatChoice is a choice of a "select", "plural", "selectordinal" or "choice" argument selected while
+// formatting a message.
+type FormatChoice struct {
+	Argument string // name of the argument
+	Key      string // key of the selected choice (i.e. "other", "=0", "1#")
+}
+
+func newFormatError(err error, argument string) *FormatError {
+	result := &FormatError{Err: err, Argument: argument}
+
+	for _, code := range formatErrorCodes {
+		if errors.Is(err, code) {
+			result.Code = code
+			break
+		}
+	}
+	return result
+}
+
+// Error returns the description of the error, without the path.
+func (x *FormatError) Error() string {
+	return x.Err.Error()
+}
+
+func (x *FormatError) Unwrap() error {
+	return x.Err
 }
 
-func (x parseError) Error() string {
-	return fmt.Sprintf("ParseError: `%s` at %d", x.msg, x.pos)
+// withChoice prepends the choice selected to format the given error, if any, to its path.
+func withChoice(err error, argument, key string) error {
+	var formatError *FormatError
+	if err != nil && errors.As(err, &formatError) {
+		formatError.Path = append([]FormatChoice{{argument, key}}, formatError.Path...)
+	}
+	return err
 }
@@ -0,0 +1,158 @@
+package messageformat
+
+import (
+	"errors"
+	"testing"
+)
+
+func doTestParseError(t *testing.T, input string) *ParseError {
+	_, err := doParse(input)
+
+	var result *ParseError
+	if !errors.As(err, &result) {
+		t.Errorf("`%s` should threw a *ParseError but got <%v>", input, err)
+		return nil
+	}
+	return result
+}
+
+func TestParseError(t *testing.T) {
+	err := doTestParseError(t, "Hello,\n\t{G, select, male{He} other{{N, foo}}}")
+	if err != nil {
+		doTestError(t, "ParseError: `UnknownType: `foo`` at 42", err)
+
+		if err.Code != ErrUnknownType || !errors.Is(err, ErrUnknownType) || errors.Is(err, ErrUnbalancedBraces) {
+			t.Errorf("Unexpected code <%v>", err.Code)
+		}
+
+		if err.Offset != 42 || err.Line != 2 || err.Column != 36 || err.Argument != "N" {
+			t.Errorf("Unexpected location <%d %d:%d `%s`>", err.Offset, err.Line, err.Column, err.Argument)
+		}
+
+		expected := "\t{G, select, male{He} other{{N, foo}}}\n\t                                  ^"
+		if snippet := err.Snippet(); snippet != expected {
+			t.Errorf("Expecting <%s> but got <%s>", expected, snippet)
+		}
+	}
+
+	err = doTestParseError(t, "{N, plural, other{#}")
+	if err != nil {
+		if err.Code != ErrUnbalancedBraces || err.Argument != "N" || err.Line != 1 || err.Column != 21 {
+			t.Errorf("Unexpected error <%v %s %d:%d>", err.Code, err.Argument, err.Line, err.Column)
+		}
+
+		expected := "{N, plural, other{#}\n                    ^"
+		if snippet := err.Snippet(); snippet != expected {
+			t.Errorf("Expecting <%s> but got <%s>", expected, snippet)
+		}
+	}
+
+	err = doTestParseError(t, "{A, select, other{{B, number, foo{}}}}")
+	if err != nil && (err.Code != ErrInvalidExpr || err.Argument != "B") {
+		t.Errorf("Unexpected error <%v %s>", err.Code, err.Argument)
+	}
+
+	o, _ := New()
+	o.Register("custom", func(string, *Parser, rune, int, int, *[]rune) (Expression, int, error) {
+		return nil, 0, errors.New("CustomError")
+	}, nil)
+
+	if _, e := o.Parse("{X, custom}"); errors.As(e, &err) {
+		if err.Code != nil || err.Err.Error() != "CustomError" || err.Argument != "X" {
+			t.Errorf("Unexpected error <%v %v %s>", err.Code, err.Err, err.Argument)
+		}
+	} else {
+		t.Errorf("Expecting a *ParseError but got <%v>", e)
+	}
+}
+
+func doTestFormatError(t *testing.T, input string, data map[string]interface{}) *FormatError {
+	o, err := doParse(input)
+	if err != nil {
+		t.Errorf("`%s` threw <%s>", input, err)
+		return nil
+	}
+
+	_, err = o.FormatMap(data)
+
+	var result *FormatError
+	if !errors.As(err, &result) {
+		t.Errorf("`%s` should threw a *FormatError but got <%v>", input, err)
+		return nil
+	}
+	return result
+}
+
+func TestFormatError(t *testing.T) {
+	err := doTestFormatError(t, "{G, select, male{{N, plural, one{#} other{{V}}}} other{}}", map[string]interface{}{
+		"G": "male",
+		"N": 2,
+		"V": struct{}{},
+	})
+	if err != nil {
+		doTestError(t, "toString: Unsupported type: struct {}", err)
+
+		if err.Code != ErrUnsupportedType || !errors.Is(err, ErrUnsupportedType) || err.Argument != "V" {
+			t.Errorf("Unexpected error <%v %s>", err.Code, err.Argument)
+		}
+
+		expected := []FormatChoice{{"G", "male"}, {"N", "other"}}
+		if len(err.Path) != len(expected) || err.Path[0] != expected[0] || err.Path[1] != expected[1] {
+			t.Errorf("Expecting path <%v> but got <%v>", expected, err.Path)
+		}
+	}
+
+	err = doTestFormatError(t, "{C, choice, 0#none|1<{D, number}}", map[string]interface{}{
+		"C": 3,
+		"D": true,
+	})
+	if err != nil {
+		if err.Code != ErrUnsupportedType || err.Argument != "D" || len(err.Path) != 1 || err.Path[0] != (FormatChoice{"C", "1<"}) {
+			t.Errorf("Unexpected error <%v %s %v>", err.Code, err.Argument, err.Path)
+		}
+	}
+
+	err = doTestFormatError(t, "{G, select, other{{user.name}}}", map[string]interface{}{
+		"G":    "x",
+		"user": map[string]interface{}{},
+	})
+	if err != nil {
+		doTestError(t, "Path: Unknown segment `name` in `user.name`", err)
+
+		if err.Code != ErrUnknownSegment || !errors.Is(err, ErrUnknownSegment) || err.Argument != "user.name" {
+			t.Errorf("Unexpected error <%v %s>", err.Code, err.Argument)
+		}
+	}
+
+	for _, ex := range []struct {
+		input string
+		data  map[string]interface{}
+		code  error
+	}{
+		{"{L, list}", map[string]interface{}{"L": []int{1}}, ErrUnsupportedType},
+		{"{N, number}", map[string]interface{}{"N": "twelve"}, ErrInvalidValue},
+		{"{N, spellout, %spellout-ordinal}", map[string]interface{}{"N": 1}, ErrUnknownRuleSet},
+		{"{D, duration}", map[string]interface{}{"D": 1e30}, ErrOutOfRange},
+	} {
+		err = doTestFormatError(t, ex.input, ex.data)
+		if err != nil && (err.Code != ex.code || !errors.Is(err, ex.code)) {
+			t.Errorf("`%s` should threw a <%v> error but got <%v>", ex.input, ex.code, err)
+		}
+	}
+
+	o, _ := New()
+
+	// no format function uses an unknown unit
+	mf, _ := o.Parse("{D, duration}")
+	if _, e := formatUnitAmount(decimal{integer: "1"}, "long", "parsec", mf); !errors.Is(e, ErrUnknownUnit) {
+		t.Errorf("Expecting an unknown unit error but got <%v>", e)
+	}
+
+	mf, _ = o.Parse("{N, plural, other{#}}")
+	mf.plural = nil
+
+	_, e := mf.FormatMap(map[string]interface{}{"N": 1})
+	if !errors.As(e, &err) || err.Code != ErrUndefinedPluralFunc || err.Argument != "N" || len(err.Path) != 0 {
+		t.Errorf("Expecting an undefined plural func error but got <%v>", e)
+	}
+}
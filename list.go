@@ -0,0 +1,116 @@
+package messageformat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+type listExpr struct {
+	key   string
+	style string // name of the list patterns, i.e. "standard", "or-short", "unit-narrow"
+}
+
+func parseList(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	result := new(listExpr)
+	result.key = varname
+	result.style = "standard"
+
+	if char == CloseChar {
+		return result, start, nil
+	}
+
+	style, pos, err := readStyle(start+1, end, ptr_input)
+	if err != nil {
+		return nil, pos, err
+	} else if style == "" {
+		return nil, pos, ErrMissingStyle
+	}
+
+	kind, width := "standard", ""
+	for _, token := range strings.Fields(style) {
+		switch token {
+		default:
+			return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+
+		case "conjunction":
+			kind = "standard"
+
+		case "disjunction":
+			kind = "or"
+
+		case "unit":
+			kind = "unit"
+
+		case "long", "wide":
+			width = ""
+
+		case "short", "narrow":
+			width = "-" + token
+		}
+	}
+
+	result.style = kind + width
+	return result, pos, nil
+}
+
+// formatList is the format function associated with the "list" type.
+//
+// It will returns an error if :
+// - the associated value is not a slice (or an array)
+// - an item of the slice is not a string or a fmt.Stringer
+//
+// It will writes nothing if its key can't be found in the given map
+func formatList(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+	o := expr.(*listExpr)
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	} else if !ok || v == nil {
+		return nil
+	}
+
+	items, err := toList(v)
+	if err != nil {
+		return err
+	}
+
+	ptr_output.WriteString(getListPattern(ptr_mf.culture, o.style).join(items))
+	return nil
+}
+
+// toList tries to convert a slice of strings or fmt.Stringer into a slice of strings.
+func toList(value interface{}) ([]string, error) {
+	switch t := value.(type) {
+	case []string:
+		return t, nil
+
+	case []fmt.Stringer:
+		result := make([]string, len(t))
+		for i, item := range t {
+			result[i] = item.String()
+		}
+		return result, nil
+	}
+
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("List: %w: %T", ErrUnsupportedType, value)
+	}
+
+	result := make([]string, v.Len())
+	for i := range result {
+		switch item := v.Index(i).Interface().(type) {
+		default:
+			return nil, fmt.Errorf("List: %w for item: %T", ErrUnsupportedType, item)
+
+		case string:
+			result[i] = item
+
+		case fmt.Stringer:
+			result[i] = item.String()
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,64 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestList(t *testing.T) {
+	names := []string{"Alice", "Bob", "Carol"}
+
+	doTest(t, Test{
+		"{L, list}|{L, list, disjunction}|{L, list, conjunction short}|{L, list, unit narrow}",
+		[]Expectation{
+			{map[string]interface{}{"L": names}, "Alice, Bob, and Carol|Alice, Bob, or Carol|Alice, Bob, & Carol|Alice Bob Carol"},
+			{map[string]interface{}{"L": names[:2]}, "Alice and Bob|Alice or Bob|Alice & Bob|Alice Bob"},
+			{map[string]interface{}{"L": names[:1]}, "Alice|Alice|Alice|Alice"},
+			{map[string]interface{}{"L": []string{}}, "|||"},
+			{map[string]interface{}{"L": [4]string{"a", "b", "c", "d"}}, "a, b, c, and d|a, b, c, or d|a, b, c, & d|a b c d"},
+			{nil, "|||"},
+		},
+	})
+
+	doTest(t, Test{
+		"{L, list}",
+		[]Expectation{
+			{map[string]interface{}{"L": []testStruct{{1}, {2}}}, "1 and 2"},
+			{map[string]interface{}{"L": []interface{}{"one", testStruct{2}}}, "one and 2"},
+		},
+	})
+
+	doTestException(
+		t,
+		"{L, list}",
+		map[string]interface{}{"L": "Alice"},
+		"List: Unsupported type: string",
+	)
+
+	doTestException(
+		t,
+		"{L, list}",
+		map[string]interface{}{"L": []int{1, 2}},
+		"List: Unsupported type for item: int",
+	)
+}
+
+func TestListCulture(t *testing.T) {
+	doTestWithCulture(t, "fr", Test{
+		"{L, list}|{L, list, disjunction}",
+		[]Expectation{
+			{map[string]interface{}{"L": []string{"Alice", "Bob", "Carol"}}, "Alice, Bob et Carol|Alice, Bob ou Carol"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{L, list}",
+		[]Expectation{
+			{map[string]interface{}{"L": []string{"Alice", "Bob", "Carol"}}, "Alice, Bob und Carol"},
+		},
+	})
+}
+
+func TestListParseException(t *testing.T) {
+	doTestParseException(t, "{L, list, }", "ParseError: `MissingStyle` at 10")
+	doTestParseException(t, "{L, list, and}", "ParseError: `InvalidStyle: `and`` at 13")
+}
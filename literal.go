@@ -4,7 +4,7 @@ import (
 	"bytes"
 )
 
-func formatLiteral(expr Expression, ptr_output *bytes.Buffer, _ *map[string]interface{}, _ *MessageFormat, pound string) error {
+func formatLiteral(expr Expression, ptr_output Writer, _ *map[string]interface{}, _ *MessageFormat, pound string) error {
 	content := expr.([]string)
 
 	for _, c := range content {
@@ -78,3 +78,45 @@ func parseLiteral(start, end int, ptr_input *[]rune) []string {
 	}
 	return result
 }
+
+// parseQuotedLiteral parses a literal text in the ApostropheDoubleOptional mode.
+//
+// As with parseLiteral, an empty string stands for an unquoted PoundChar.
+func parseQuotedLiteral(start, end int, ptr_input *[]rune, parent string) []string {
+	var result []string
+	var buf bytes.Buffer
+
+	input := *ptr_input
+	quoted := false
+
+	for i := start; i < end; i++ {
+		c := input[i]
+
+		switch {
+		case c == QuoteChar && i+1 < end && input[i+1] == QuoteChar:
+			buf.WriteRune(QuoteChar)
+			i++
+
+		case c == QuoteChar && quoted:
+			quoted = false
+
+		case c == QuoteChar && i+1 < end && isSyntaxChar(input[i+1], parent):
+			quoted = true
+
+		case c == PoundChar && !quoted:
+			if buf.Len() != 0 {
+				result = append(result, buf.String())
+				buf.Reset()
+			}
+			result = append(result, "")
+
+		default:
+			buf.WriteRune(c)
+		}
+	}
+
+	if buf.Len() != 0 {
+		result = append(result, buf.String())
+	}
+	return result
+}
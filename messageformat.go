@@ -4,15 +4,63 @@
 package messageformat
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"github.com/gotnospirit/makeplural/plural"
+	"io"
+	"strconv"
+	"time"
 )
 
-type MessageFormat struct {
-	root       node
-	formatters map[string]formatFunc
-	plural     pluralFunc
+type (
+	MessageFormat struct {
+		root       node
+		source     []rune
+		apostrophe ApostropheMode // mode of the parsed source
+		formatters map[string]formatFunc
+		plural     pluralFunc
+		culture    string
+		location   *time.Location
+		now        time.Time
+		rawPound   bool
+		missing    MissingMode
+	}
+
+	// A FormatOption customizes a single formatting call.
+	FormatOption func(*MessageFormat)
+)
+
+// WithTimeZone sets the location used to render the "date" and "time" arguments.
+//
+// Without it, a time.Time is rendered in its own location and a Unix timestamp in UTC.
+func WithTimeZone(loc *time.Location) FormatOption {
+	return func(x *MessageFormat) {
+		x.location = loc
+	}
+}
+
+// WithNow sets the reference time of the "relativetime" arguments, the current time being used otherwise.
+func WithNow(now time.Time) FormatOption {
+	return func(x *MessageFormat) {
+		x.now = now
+	}
+}
+
+// WithRawPound keeps the "#" of the "plural" and "selectordinal" choices in the raw form of their value,
+// instead of formatting it as a number of the culture.
+func WithRawPound() FormatOption {
+	return func(x *MessageFormat) {
+		x.rawPound = true
+	}
+}
+
+// WithMissingMode sets how the missing arguments are rendered, instead of the mode of the Parser
+// (see Parser.SetMissingMode).
+func WithMissingMode(mode MissingMode) FormatOption {
+	return func(x *MessageFormat) {
+		x.missing = mode
+	}
 }
 
 func (x *MessageFormat) SetCulture(name string) error {
@@ -21,6 +69,7 @@ func (x *MessageFormat) SetCulture(name string) error {
 		return err
 	}
 	x.plural = fn
+	x.culture = name
 	return nil
 }
 
@@ -32,23 +81,102 @@ func (x *MessageFormat) SetPluralFunction(fn pluralFunc) error {
 	return nil
 }
 
-func (x *MessageFormat) Format() (string, error) {
-	return x.FormatMap(nil)
+func (x *MessageFormat) Format(options ...FormatOption) (string, error) {
+	return x.FormatMap(nil, options...)
 }
 
-func (x *MessageFormat) FormatMap(data map[string]interface{}) (string, error) {
+func (x *MessageFormat) FormatMap(data map[string]interface{}, options ...FormatOption) (string, error) {
 	var buf bytes.Buffer
 
-	err := x.root.format(&buf, &data, x, "")
+	mf := x.with(options)
+	err := mf.root.format(&buf, &data, mf, "")
 	if err != nil {
 		return "", err
 	}
 	return buf.String(), nil
 }
 
+// FormatTo formats the message straight into the given writer.
+//
+// A writer which is not a Writer is buffered, and flushed once the message is completely formatted.
+// The first write error is returned; on error, part of the message may have been written.
+func (x *MessageFormat) FormatTo(w io.Writer, data map[string]interface{}, options ...FormatOption) error {
+	mf := x.with(options)
+
+	if output, ok := w.(Writer); ok {
+		ew := &errorWriter{w: output}
+		err := mf.root.format(ew, &data, mf, "")
+		if err != nil {
+			return err
+		}
+		return ew.err
+	}
+
+	output := bufio.NewWriter(w)
+	err := mf.root.format(output, &data, mf, "")
+	if err != nil {
+		return err
+	}
+	return output.Flush()
+}
+
+// errorWriter is a Writer keeping the first error of the underlying writer, the next writes being dropped.
+type errorWriter struct {
+	w   Writer
+	err error
+}
+
+func (x *errorWriter) Write(p []byte) (int, error) {
+	if x.err != nil {
+		return 0, x.err
+	}
+	n, err := x.w.Write(p)
+	x.err = err
+	return n, err
+}
+
+func (x *errorWriter) WriteByte(c byte) error {
+	if x.err == nil {
+		x.err = x.w.WriteByte(c)
+	}
+	return x.err
+}
+
+func (x *errorWriter) WriteString(s string) (int, error) {
+	if x.err != nil {
+		return 0, x.err
+	}
+	n, err := x.w.WriteString(s)
+	x.err = err
+	return n, err
+}
+
+func (x *errorWriter) WriteRune(r rune) (int, error) {
+	if x.err != nil {
+		return 0, x.err
+	}
+	n, err := x.w.WriteRune(r)
+	x.err = err
+	return n, err
+}
+
+// FormatSlice formats the message with positional arguments: the value at index i is bound to the "{i}" argument.
+func (x *MessageFormat) FormatSlice(args ...interface{}) (string, error) {
+	return x.FormatSliceWith(args)
+}
+
+// FormatSliceWith formats the message with positional arguments, as FormatSlice does, customized by the given options.
+func (x *MessageFormat) FormatSliceWith(args []interface{}, options ...FormatOption) (string, error) {
+	data := make(map[string]interface{}, len(args))
+	for i, arg := range args {
+		data[strconv.Itoa(i)] = arg
+	}
+	return x.FormatMap(data, options...)
+}
+
 func (x *MessageFormat) getNamedKey(value interface{}, ordinal bool) (string, error) {
 	if x.plural == nil {
-		return "", fmt.Errorf("UndefinedPluralFunc")
+		return "", ErrUndefinedPluralFunc
 	}
 	return x.plural(value, ordinal), nil
 }
@@ -56,9 +184,22 @@ func (x *MessageFormat) getNamedKey(value interface{}, ordinal bool) (string, er
 func (x *MessageFormat) getFormatter(key string) (formatFunc, error) {
 	fn, ok := x.formatters[key]
 	if !ok {
-		return nil, fmt.Errorf("UnknownType: `%s`", key)
+		return nil, fmt.Errorf("%w: `%s`", ErrUnknownType, key)
 	} else if fn == nil {
-		return nil, fmt.Errorf("UndefinedFormatFunc: `%s`", key)
+		return nil, fmt.Errorf("%w: `%s`", ErrUndefinedFormatFunc, key)
 	}
 	return fn, nil
 }
+
+// with returns a copy of the MessageFormat customized by the given options, or itself when there is none.
+func (x *MessageFormat) with(options []FormatOption) *MessageFormat {
+	if len(options) == 0 {
+		return x
+	}
+
+	result := *x
+	for _, option := range options {
+		option(&result)
+	}
+	return &result
+}
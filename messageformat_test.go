@@ -3,7 +3,11 @@
 package messageformat
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -70,3 +74,141 @@ func TestSetPluralFunction(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatSlice(t *testing.T) {
+	mf, err := doParse(`{0} has {1, plural, =0{no file} one{# file} other{# files}} in {2, select, home{his home} other{{2}}}{3, choice, 0#|1#, {3, number} shared}.`)
+	if err != nil {
+		t.Errorf("Unexpected parse failure: `%s`", err.Error())
+		return
+	}
+
+	expectations := []struct {
+		args   []interface{}
+		output string
+	}{
+		{[]interface{}{"Bob", 0, "home", 0}, "Bob has no file in his home."},
+		{[]interface{}{"Bob", 1, "the cloud", 1}, "Bob has 1 file in the cloud, 1 shared."},
+		{[]interface{}{"Alice", 1500, "the cloud", 1200}, "Alice has 1,500 files in the cloud, 1,200 shared."},
+		{[]interface{}{"Alice", 2}, "Alice has 2 files in ."},
+	}
+
+	for _, ex := range expectations {
+		result, err := mf.FormatSlice(ex.args...)
+		if err != nil {
+			t.Errorf("Unexpected error <%s>", err)
+		} else if result != ex.output {
+			t.Errorf("Expecting <%v> but got <%v>", ex.output, result)
+		} else if testing.Verbose() {
+			fmt.Printf("- Got expected value <%s>\n", result)
+		}
+	}
+
+	result, err := mf.FormatSliceWith([]interface{}{"Bob", 2}, WithMissingMode(MissingPlaceholder), WithRawPound())
+	if err != nil || result != "Bob has 2 files in {2}{3}." {
+		t.Errorf("Unexpected result <%s> <%v>", result, err)
+	}
+}
+
+// testWriter is an io.Writer which is not a Writer, failing after a number of bytes.
+type testWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (x *testWriter) Write(p []byte) (int, error) {
+	if x.buf.Len()+len(p) > x.limit {
+		return 0, errors.New("WriterFull")
+	}
+	return x.buf.Write(p)
+}
+
+func TestFormatTo(t *testing.T) {
+	mf, err := doParse(`{N, plural, one{# file} other{# files}} in {D, select, home{his home} other{{D}}}.`)
+	if err != nil {
+		t.Errorf("Unexpected parse failure: `%s`", err.Error())
+		return
+	}
+
+	data := map[string]interface{}{"N": 1200, "D": "home"}
+	expected := "1,200 files in his home."
+
+	var builder strings.Builder
+	if err := mf.FormatTo(&builder, data); err != nil {
+		t.Errorf("Unexpected error <%s>", err)
+	} else if builder.String() != expected {
+		t.Errorf("Expecting <%v> but got <%v>", expected, builder.String())
+	}
+
+	w := &testWriter{limit: 100}
+	if err := mf.FormatTo(w, data, WithRawPound()); err != nil {
+		t.Errorf("Unexpected error <%s>", err)
+	} else if w.buf.String() != "1200 files in his home." {
+		t.Errorf("Expecting <%v> but got <%v>", "1200 files in his home.", w.buf.String())
+	}
+
+	err = mf.FormatTo(&testWriter{limit: 10}, data)
+	doTestError(t, "WriterFull", err)
+
+	// a Writer is used as is, its errors are returned too
+	err = mf.FormatTo(bufio.NewWriterSize(&testWriter{limit: 10}, 16), data)
+	doTestError(t, "WriterFull", err)
+
+	err = mf.FormatTo(&builder, map[string]interface{}{"N": struct{}{}})
+	doTestError(t, "toString: Unsupported type: struct {}", err)
+}
+
+func TestMissingMode(t *testing.T) {
+	input := "{NAME} has {N, plural, one{# file} other{# files}} in {P, select, home{his home} other{{WHERE}}}."
+
+	doTestWithOptions(t, "en", Test{input, []Expectation{
+		{map[string]interface{}{"NAME": "Bob", "N": 1, "P": "home"}, "Bob has 1 file in his home."},
+		{map[string]interface{}{"N": 2}, "{NAME} has 2 files in {P}."},
+		{map[string]interface{}{"P": "away"}, "{NAME} has {N} in {WHERE}."},
+		{nil, "{NAME} has {N} in {P}."},
+	}}, WithMissingMode(MissingPlaceholder))
+
+	o, _ := New()
+	if err := o.SetMissingMode(MissingError); err != nil {
+		t.Errorf("Unexpected error <%s>", err)
+	}
+
+	mf, err := o.Parse(input)
+	if err != nil {
+		t.Errorf("Unexpected parse failure: `%s`", err.Error())
+		return
+	}
+
+	_, err = mf.FormatMap(map[string]interface{}{"NAME": "Bob", "N": 2, "P": "away"})
+	doTestError(t, "MissingArgument: `WHERE`", err)
+
+	var formatError *FormatError
+	if !errors.As(err, &formatError) || formatError.Code != ErrMissingArgument || formatError.Argument != "WHERE" ||
+		len(formatError.Path) != 1 || formatError.Path[0] != (FormatChoice{"P", "other"}) {
+		t.Errorf("Unexpected error <%v>", err)
+	}
+
+	_, err = mf.FormatMap(map[string]interface{}{"NAME": "Bob"})
+	if !errors.Is(err, ErrMissingArgument) {
+		t.Errorf("Expecting a missing argument error but got <%v>", err)
+	}
+
+	// a formatting call overrides the mode of the parser
+	if result, err := mf.FormatMap(nil, WithMissingMode(MissingEmpty)); err != nil || result != " has # files in ." {
+		t.Errorf("Unexpected result <%s> <%v>", result, err)
+	}
+
+	for _, mode := range []MissingMode{MissingError, MissingPlaceholder} {
+		mf, _ := o.Parse("Hello {user.name}!")
+		result, err := mf.FormatMap(map[string]interface{}{"user": map[string]interface{}{}}, WithMissingMode(mode))
+
+		if mode == MissingError && !errors.Is(err, ErrMissingArgument) {
+			t.Errorf("Expecting a missing argument error but got <%v>", err)
+		} else if mode == MissingPlaceholder && (err != nil || result != "Hello {user.name}!") {
+			t.Errorf("Unexpected result <%s> <%v>", result, err)
+		}
+	}
+
+	if o.SetMissingMode(MissingMode(42)) == nil {
+		t.Errorf("Expecting an error for an unknown mode")
+	}
+}
@@ -1,37 +1,76 @@
 package messageformat
 
-import "bytes"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 type (
 	Expression interface{}
 
 	node struct {
-		children []*nodeExpr
+		children   []*nodeExpr
+		start, end int    // offsets of the message in the input
+		parent     string // type of the argument holding the message, empty for the root
 	}
 
 	nodeExpr struct {
-		ctype string
-		expr  Expression
+		ctype      string
+		expr       Expression
+		start, end int // offsets of the expression in the input
 	}
 )
 
-func (x *node) add(ctype string, child Expression) {
-	x.children = append(x.children, &nodeExpr{ctype, child})
+func (x *node) add(ctype string, child Expression, start, end int) {
+	x.children = append(x.children, &nodeExpr{ctype, child, start, end})
 }
 
-func (x *node) format(ptr_output *bytes.Buffer, data *map[string]interface{}, ptr_mf *MessageFormat, pound string) error {
+func (x *node) format(ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, pound string) error {
 	for _, child := range x.children {
 		ctype := child.ctype
 
+		if ctype != "literal" && ptr_mf.missing != MissingEmpty {
+			name := child.argument(ptr_mf.source)
+
+			// an unknown segment of a path is a missing argument too
+			if _, ok, err := lookup(*data, name); !ok || err != nil {
+				if ptr_mf.missing == MissingError {
+					return newFormatError(fmt.Errorf("%w: `%s`", ErrMissingArgument, name), name)
+				}
+
+				ptr_output.WriteString(string(OpenChar) + name + string(CloseChar))
+				continue
+			}
+		}
+
 		fn, err := ptr_mf.getFormatter(ctype)
-		if err != nil {
-			return err
+		if err == nil {
+			err = fn(child.expr, ptr_output, data, ptr_mf, pound)
 		}
 
-		err = fn(child.expr, ptr_output, data, ptr_mf, pound)
 		if err != nil {
-			return err
+			var formatError *FormatError
+			if errors.As(err, &formatError) {
+				return err
+			}
+			return newFormatError(err, child.argument(ptr_mf.source))
 		}
 	}
 	return nil
 }
+
+// argument returns the name of the argument of the expression, or an empty string for a literal text.
+func (x *nodeExpr) argument(source []rune) string {
+	switch x.ctype {
+	case "literal":
+		return ""
+
+	case "var":
+		return x.expr.(string)
+	}
+
+	// the name can't hold a PartChar
+	name := strings.SplitN(string(source[x.start+1:x.end-1]), string(PartChar), 2)[0]
+	return strings.TrimSpace(name)
+}
@@ -0,0 +1,663 @@
+package messageformat
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type numberExpr struct {
+	key      string
+	style    string          // named style ("decimal", "integer", "percent", "currency"), custom pattern or skeleton
+	pattern  *numberPattern  // compiled custom pattern, nil for a named style
+	skeleton *numberSkeleton // compiled skeleton, nil for a named style or a custom pattern
+}
+
+// A numberPattern is a compiled ICU decimal pattern (i.e. "#,##0.00;(#,##0.00)").
+//
+// Its affixes are kept unexpanded, so that the same pattern can be rendered with the symbols of any culture.
+type numberPattern struct {
+	prefix, suffix       string
+	negPrefix, negSuffix string
+	minInt, maxInt       int // maxInt is -1 when unlimited
+	minFrac, maxFrac     int // maxFrac is -1 when unlimited
+	minSig, maxSig       int // significant digits, 0 when unused, maxSig is -1 when unlimited
+	increment            *decimal
+	rounding             roundingMode
+	grouping, grouping2  int // primary and secondary grouping sizes, 0 when grouping is disabled
+	minGrouping          int // 0 to use the culture's one
+	multiplier           int // power of ten applied to the value (2 for percent, 3 for per mille)
+	scale                *decimal
+	notation             string // "", "scientific", "engineering", "compact-short" or "compact-long"
+	sign                 string // "", "always", "never", "except-zero", "accounting", "accounting-always", "accounting-except-zero" or "negative"
+	decimalAlways        bool
+	currency             string // ISO 4217 code, empty to use the culture's one
+	currencyDisplay      string // "", "iso-code", "narrow", "hidden" or "full-name"
+}
+
+func parseNumber(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	result := new(numberExpr)
+	result.key = varname
+	result.style = "decimal"
+
+	if char == CloseChar {
+		return result, start, nil
+	}
+
+	style, pos, err := readStyle(start+1, end, ptr_input)
+	if err != nil {
+		return nil, pos, err
+	}
+
+	switch {
+	case style == "":
+		return nil, pos, ErrMissingStyle
+
+	case style == "integer", style == "percent", style == "currency":
+		result.style = style
+
+	case strings.HasPrefix(style, "::"):
+		skeleton, err := compileNumberSkeleton(style[2:])
+		if err != nil {
+			return nil, pos, err
+		}
+		result.style = style
+		result.skeleton = skeleton
+
+	default:
+		pattern, err := compileNumberPattern(style)
+		if err != nil {
+			return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+		}
+		result.style = style
+		result.pattern = pattern
+	}
+	return result, pos, nil
+}
+
+// formatNumber is the format function associated with the "number" type.
+//
+// It will returns an error if :
+// - the associated value is not numeric or is a string that can't be parsed as a number
+// - the pluralFunc is not defined (MessageFormat.getNamedKey) and a "compact-long" notation is used
+//
+// It will writes nothing if its key can't be found in the given map
+func formatNumber(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+	o := expr.(*numberExpr)
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	} else if !ok || v == nil {
+		return nil
+	}
+
+	value, err := toDecimal(v)
+	if err != nil {
+		return err
+	}
+
+	symbols := getNumberSymbols(ptr_mf.culture)
+
+	pattern := o.pattern
+	if o.skeleton != nil {
+		pattern = o.skeleton.resolve(symbols)
+	} else if pattern == nil {
+		pattern = symbols.patterns[o.style]
+
+		if o.style == "currency" {
+			pattern = pattern.withCurrencyDigits(symbols.currency)
+		}
+	}
+
+	var result string
+	if o.skeleton != nil && o.skeleton.unit != "" {
+		result, err = formatMeasure(value, pattern, symbols, o.skeleton.unit, pattern.unitWidth(), ptr_mf)
+	} else {
+		result, err = pattern.format(value, symbols, ptr_mf)
+	}
+
+	if err != nil {
+		return err
+	}
+	ptr_output.WriteString(result)
+	return nil
+}
+
+// formatPound returns the value written in place of the "#" of a "plural" or "selectordinal" choice.
+//
+// The value is formatted as a decimal number of the culture, keeping all its digits (i.e. "3.00").
+// It is returned as is when it is not a number, when its exponent is too large to be expanded (see parseDecimal)
+// or when the raw form was requested (see WithRawPound).
+func (x *MessageFormat) formatPound(value string) string {
+	if x.rawPound {
+		return value
+	}
+
+	number, ok := parseDecimal(value)
+	if !ok {
+		return value
+	}
+
+	return formatDigits(number, x.culture)
+}
+
+// formatDigits formats a value as a decimal number of the culture, keeping all its fractional digits.
+func formatDigits(value decimal, culture string) string {
+	symbols := getNumberSymbols(culture)
+
+	pattern := *symbols.patterns["decimal"]
+	pattern.minFrac, pattern.maxFrac = len(value.fraction), -1
+	return pattern.render(value, symbols, "", "")
+}
+
+// readStyle returns the raw style of an expression, that is everything up to its closing brace.
+//
+// Apostrophes may be used to quote braces which are then part of the style.
+func readStyle(start, end int, ptr_input *[]rune) (string, int, error) {
+	input := *ptr_input
+	quoted := false
+
+	for pos := start; pos < end; pos++ {
+		switch input[pos] {
+		case '\'':
+			quoted = !quoted
+
+		case OpenChar:
+			if !quoted {
+				return "", pos, ErrInvalidExpr
+			}
+
+		case CloseChar:
+			if !quoted {
+				return strings.TrimSpace(string(input[start:pos])), pos, nil
+			}
+		}
+	}
+	return "", end, ErrUnbalancedBraces
+}
+
+// compileNumberPattern parses an ICU decimal pattern.
+func compileNumberPattern(input string) (*numberPattern, error) {
+	positive, negative := splitNumberPattern(input)
+
+	prefix, number, suffix, err := splitAffixes(positive)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(numberPattern)
+	result.prefix, result.suffix = prefix, suffix
+	result.negPrefix, result.negSuffix = "-"+prefix, suffix
+	result.maxInt = -1
+
+	if negative != "" {
+		prefix, _, suffix, err := splitAffixes(negative)
+		if err != nil {
+			return nil, err
+		}
+		result.negPrefix, result.negSuffix = prefix, suffix
+	}
+
+	integer, fraction := number, ""
+	if i := strings.IndexByte(number, '.'); i != -1 {
+		integer, fraction = number[:i], number[i+1:]
+		if strings.IndexByte(fraction, '.') != -1 || strings.IndexByte(fraction, ',') != -1 {
+			return nil, errors.New("MalformedPattern")
+		}
+	}
+
+	groups := strings.Split(integer, ",")
+	for i, group := range groups {
+		if group == "" && i > 0 {
+			return nil, errors.New("MalformedPattern")
+		}
+	}
+
+	if n := len(groups); n > 1 {
+		result.grouping = len(groups[n-1])
+		result.grouping2 = result.grouping
+		if n > 2 {
+			result.grouping2 = len(groups[n-2])
+		}
+	}
+
+	digits := strings.Replace(integer, ",", "", -1)
+	if i := strings.IndexByte(digits, '0'); i != -1 {
+		if strings.IndexByte(digits[i:], '#') != -1 {
+			return nil, errors.New("MalformedPattern")
+		}
+		result.minInt = len(digits) - i
+	}
+
+	result.minFrac = strings.Count(fraction, "0")
+	result.maxFrac = len(fraction)
+	if strings.TrimLeft(fraction, "0") != strings.Repeat("#", result.maxFrac-result.minFrac) {
+		return nil, errors.New("MalformedPattern")
+	}
+
+	for _, c := range stripQuoted(result.prefix + result.suffix) {
+		switch c {
+		case '%':
+			result.multiplier = 2
+
+		case '‰':
+			result.multiplier = 3
+		}
+	}
+	return result, nil
+}
+
+// splitNumberPattern splits a pattern into its positive and negative subpatterns.
+func splitNumberPattern(input string) (string, string) {
+	quoted := false
+	for i, c := range input {
+		switch c {
+		case '\'':
+			quoted = !quoted
+
+		case ';':
+			if !quoted {
+				return input[:i], input[i+1:]
+			}
+		}
+	}
+	return input, ""
+}
+
+// splitAffixes splits a subpattern into its prefix, number and suffix parts.
+func splitAffixes(input string) (string, string, string, error) {
+	start, end := -1, -1
+	quoted := false
+
+	for i, c := range input {
+		switch c {
+		case '\'':
+			quoted = !quoted
+
+		case '#', '0', ',', '.':
+			if quoted {
+				continue
+			} else if end != -1 {
+				return "", "", "", errors.New("MalformedPattern")
+			} else if start == -1 {
+				start = i
+			}
+			continue
+		}
+
+		if start != -1 && end == -1 {
+			end = i
+		}
+	}
+
+	if start == -1 {
+		return "", "", "", errors.New("MissingDigits")
+	} else if end == -1 {
+		end = len(input)
+	}
+	return input[:start], input[start:end], input[end:], nil
+}
+
+// stripQuoted removes the quoted parts of an affix.
+func stripQuoted(affix string) string {
+	var buf bytes.Buffer
+
+	quoted := false
+	for _, c := range affix {
+		if c == '\'' {
+			quoted = !quoted
+		} else if !quoted {
+			buf.WriteRune(c)
+		}
+	}
+	return buf.String()
+}
+
+// unquote removes the quotes of an affix, a doubled apostrophe standing for a single one.
+func unquote(affix string) string {
+	var buf bytes.Buffer
+
+	runes := []rune(affix)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '\'' {
+			buf.WriteRune(runes[i])
+		} else if i+1 < len(runes) && runes[i+1] == '\'' {
+			buf.WriteRune('\'')
+			i++
+		}
+	}
+	return buf.String()
+}
+
+// withCurrencyDigits returns a copy of the pattern using the fraction digits of the given currency.
+func (x *numberPattern) withCurrencyDigits(currency string) *numberPattern {
+	digits, ok := currencyDigits[currency]
+	if !ok {
+		digits = 2
+	}
+
+	result := *x
+	result.minFrac, result.maxFrac = digits, digits
+	return &result
+}
+
+// format renders the value using the given symbols.
+func (x *numberPattern) format(value decimal, symbols *numberSymbols, ptr_mf *MessageFormat) (string, error) {
+	if x.scale != nil {
+		value.multiply(*x.scale)
+	}
+	value.shift(x.multiplier)
+
+	switch x.notation {
+	case "scientific", "engineering":
+		return x.formatScientific(value, symbols), nil
+
+	case "compact-short":
+		return x.formatCompact(value, symbols, symbols.compactShort, ptr_mf)
+
+	case "compact-long":
+		return x.formatCompact(value, symbols, symbols.compactLong, ptr_mf)
+	}
+
+	x.round(&value)
+	return x.render(value, symbols, "", ""), nil
+}
+
+// formatScientific renders the value using the scientific (or engineering) notation.
+func (x *numberPattern) formatScientific(value decimal, symbols *numberSymbols) string {
+	step := 1
+	if x.notation == "engineering" {
+		step = 3
+	}
+
+	exponent := 0
+	if !value.isZero() {
+		magnitude := value.magnitude()
+		exponent = magnitude - (magnitude%step+step)%step
+	}
+
+	value.shift(-exponent)
+	x.round(&value)
+
+	// rounding may have produced an extra digit (i.e. 9.99 -> 10.0)
+	if !value.isZero() && value.magnitude() >= step {
+		value.shift(-step)
+		exponent += step
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(symbols.exponent)
+	if exponent < 0 {
+		buf.WriteString(symbols.minus)
+		exponent = -exponent
+	}
+	buf.WriteString(strconv.Itoa(exponent))
+	return x.render(value, symbols, "", buf.String())
+}
+
+// formatCompact renders the value using the compact notation.
+//
+// When no precision is given, the value is rounded to an integer if it has 2 or more integer digits,
+// to 2 significant digits otherwise.
+func (x *numberPattern) formatCompact(value decimal, symbols *numberSymbols, patterns map[int]map[string]string, ptr_mf *MessageFormat) (string, error) {
+	magnitude := value.magnitude()
+
+	for i := 0; i < 2; i++ {
+		forms, power := findCompactPattern(patterns, magnitude)
+
+		scaled := value
+		scaled.shift(-power)
+
+		pattern := *x
+		if pattern.minGrouping == 0 {
+			pattern.minGrouping = 2
+		}
+
+		if x.maxFrac == -1 && x.maxSig == 0 && x.increment == nil {
+			if scaled.magnitude() > 0 {
+				pattern.maxFrac = 0
+			} else {
+				pattern.maxSig = 2
+			}
+		}
+		pattern.round(&scaled)
+
+		// rounding may have produced an extra digit (i.e. 999.9K -> 1000K)
+		if i == 0 && !scaled.isZero() && scaled.magnitude()+power > magnitude {
+			magnitude++
+			continue
+		}
+
+		if forms == nil {
+			return pattern.render(scaled, symbols, "", ""), nil
+		}
+
+		plain := scaled
+		plain.trim(0)
+
+		category, err := ptr_mf.getNamedKey(plain.String(), false)
+		if err != nil {
+			return "", err
+		}
+
+		form, ok := forms[category]
+		if !ok {
+			form = forms["other"]
+		}
+
+		start, end := strings.IndexByte(form, '0'), strings.LastIndexByte(form, '0')+1
+		return pattern.render(scaled, symbols, unquote(form[:start]), unquote(form[end:])), nil
+	}
+	return "", nil
+}
+
+// findCompactPattern returns the compact forms to use for a number of the given magnitude,
+// and the power of ten by which that number must be divided.
+func findCompactPattern(patterns map[int]map[string]string, magnitude int) (map[string]string, int) {
+	for power := magnitude; power > magnitude-3 && power > 0; power-- {
+		if forms, ok := patterns[power]; ok {
+			return forms, power
+		}
+	}
+	return nil, 0
+}
+
+// round applies the rounding settings of the pattern to the value.
+func (x *numberPattern) round(ptr_value *decimal) {
+	switch {
+	case x.increment != nil:
+		ptr_value.roundIncrement(*x.increment, x.rounding)
+
+	case x.maxSig > 0:
+		ptr_value.round(x.maxSig-ptr_value.magnitude()-1, x.rounding)
+
+	case x.maxSig == 0 && x.maxFrac >= 0:
+		ptr_value.round(x.maxFrac, x.rounding)
+	}
+}
+
+// render writes a rounded value, surrounded by the affixes of the pattern.
+//
+// The inner affixes are written between the number and the pattern's affixes (i.e. "K" for compact notations).
+func (x *numberPattern) render(value decimal, symbols *numberSymbols, innerPrefix, innerSuffix string) string {
+	var buf bytes.Buffer
+
+	minFrac := x.minFrac
+	if x.minSig > 0 && x.minSig-value.magnitude()-1 > minFrac {
+		minFrac = x.minSig - value.magnitude() - 1
+	}
+	if x.increment != nil && len(x.increment.fraction) > minFrac {
+		minFrac = len(x.increment.fraction)
+	}
+	value.trim(minFrac)
+
+	// as with ICU, a negative value rounded to zero keeps its sign (i.e. "-0"), unless zero is explicitly unsigned
+	zero := value.isZero()
+	neg := value.neg && !zero
+
+	prefix, suffix := x.prefix, x.suffix
+	switch x.sign {
+	case "never":
+
+	case "always":
+		if value.neg {
+			prefix, suffix = x.negPrefix, x.negSuffix
+		} else {
+			prefix = "+" + prefix
+		}
+
+	case "except-zero":
+		if neg {
+			prefix, suffix = x.negPrefix, x.negSuffix
+		} else if !zero {
+			prefix = "+" + prefix
+		}
+
+	case "accounting", "accounting-always", "accounting-except-zero":
+		if neg {
+			prefix, suffix = "("+prefix, suffix+")"
+		} else if x.sign == "accounting-always" || (x.sign == "accounting-except-zero" && !zero) {
+			prefix = "+" + prefix
+		}
+
+	default:
+		if value.neg {
+			prefix, suffix = x.negPrefix, x.negSuffix
+		}
+	}
+
+	x.writeAffix(&buf, prefix, symbols)
+	buf.WriteString(innerPrefix)
+
+	integer := value.integer
+	if x.maxInt >= 0 && len(integer) > x.maxInt {
+		integer = integer[len(integer)-x.maxInt:]
+	}
+	if len(integer) < x.minInt {
+		integer = strings.Repeat("0", x.minInt-len(integer)) + integer
+	} else if integer == "" && value.fraction == "" {
+		integer = "0"
+	}
+	x.writeInteger(&buf, integer, symbols)
+
+	if value.fraction != "" || x.decimalAlways {
+		buf.WriteString(symbols.decimal)
+		buf.WriteString(value.fraction)
+	}
+
+	buf.WriteString(innerSuffix)
+	x.writeAffix(&buf, suffix, symbols)
+	return buf.String()
+}
+
+// writeInteger writes the integer digits, inserting the grouping separators.
+func (x *numberPattern) writeInteger(ptr_output Writer, digits string, symbols *numberSymbols) {
+	minGrouping := x.minGrouping
+	if minGrouping == 0 {
+		minGrouping = symbols.minGrouping
+	}
+
+	n := len(digits)
+	if x.grouping == 0 || n < x.grouping+minGrouping {
+		ptr_output.WriteString(digits)
+		return
+	}
+
+	var groups []string
+
+	i := n - x.grouping
+	groups = append(groups, digits[i:])
+	for i > x.grouping2 {
+		groups = append(groups, digits[i-x.grouping2:i])
+		i -= x.grouping2
+	}
+	groups = append(groups, digits[:i])
+
+	for i := len(groups) - 1; i >= 0; i-- {
+		ptr_output.WriteString(groups[i])
+		if i > 0 {
+			ptr_output.WriteString(symbols.group)
+		}
+	}
+}
+
+// writeAffix expands the special characters of an affix with the given symbols.
+func (x *numberPattern) writeAffix(ptr_output Writer, affix string, symbols *numberSymbols) {
+	var buf bytes.Buffer
+
+	runes := []rune(affix)
+	quoted := false
+	hidden := false
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+
+		switch {
+		case c == '\'':
+			if i+1 < len(runes) && runes[i+1] == '\'' {
+				buf.WriteRune(c)
+				i++
+			} else {
+				quoted = !quoted
+			}
+
+		case quoted:
+			buf.WriteRune(c)
+
+		case c == '%':
+			buf.WriteString(symbols.percent)
+
+		case c == '‰':
+			buf.WriteString(symbols.permille)
+
+		case c == '-':
+			buf.WriteString(symbols.minus)
+
+		case c == '+':
+			buf.WriteString(symbols.plus)
+
+		case c == '¤':
+			currency := x.currency
+			if currency == "" {
+				currency = symbols.currency
+			}
+
+			display := x.currencyDisplay
+			for i+1 < len(runes) && runes[i+1] == '¤' {
+				display = "iso-code"
+				i++
+			}
+
+			switch display {
+			case "iso-code":
+				buf.WriteString(currency)
+
+			case "narrow":
+				if symbol, ok := narrowCurrencySymbols[currency]; ok {
+					buf.WriteString(symbol)
+				} else {
+					buf.WriteString(symbols.currencySymbol(currency))
+				}
+
+			case "hidden":
+				hidden = true
+
+			default:
+				buf.WriteString(symbols.currencySymbol(currency))
+			}
+
+		default:
+			buf.WriteRune(c)
+		}
+	}
+
+	if hidden {
+		ptr_output.WriteString(strings.TrimFunc(buf.String(), unicode.IsSpace))
+	} else {
+		ptr_output.Write(buf.Bytes())
+	}
+}
@@ -0,0 +1,369 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A numberSkeleton is a compiled ICU number skeleton (i.e. "compact-short currency/EUR precision-integer").
+//
+// It is resolved against the patterns of a culture when formatting.
+// see https://unicode-org.github.io/icu/userguide/format_parse/numbers/skeletons.html
+type numberSkeleton struct {
+	style             string // pattern of the culture to start from: "decimal", "percent", "permille" or "currency"
+	currency          string // ISO 4217 code, empty to use the culture's one
+	unit              string // measure unit (i.e. "kilometer"), empty for none
+	notation          string
+	fullName          bool                   // true if the "unit-width-full-name" stem was given
+	currencyPrecision bool                   // true to use the fraction digits of the currency
+	precision         func(*numberPattern)   // nil to use the default precision
+	options           []func(*numberPattern) // applied in order
+}
+
+var (
+	roundingModes = map[string]roundingMode{
+		"ceiling":   roundCeiling,
+		"floor":     roundFloor,
+		"down":      roundDown,
+		"up":        roundUp,
+		"half-even": roundHalfEven,
+		"half-down": roundHalfDown,
+		"half-up":   roundHalfUp,
+	}
+
+	// concise forms of the skeleton stems
+	conciseStems = map[string]string{
+		"K":   "compact-short",
+		"KK":  "compact-long",
+		"E0":  "scientific",
+		"EE0": "engineering",
+		"%":   "percent",
+		".":   "precision-integer",
+		",_":  "group-off",
+		",?":  "group-min2",
+		",!":  "group-on-aligned",
+		",=":  "group-thousands",
+		"+!":  "sign-always",
+		"+_":  "sign-never",
+		"+?":  "sign-except-zero",
+		"+-":  "sign-negative",
+		"()":  "sign-accounting",
+		"()!": "sign-accounting-always",
+		"()?": "sign-accounting-except-zero",
+	}
+)
+
+// compileNumberSkeleton parses the space separated tokens of an ICU number skeleton.
+func compileNumberSkeleton(input string) (*numberSkeleton, error) {
+	result := new(numberSkeleton)
+	result.style = "decimal"
+
+	for _, token := range strings.Fields(input) {
+		err := result.add(token)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// the display names of the currencies are not available
+	if result.style == "currency" && result.fullName {
+		return nil, fmt.Errorf("%w: `%s`", ErrInvalidSkeleton, "unit-width-full-name")
+	}
+	return result, nil
+}
+
+// add interprets a single skeleton token.
+func (x *numberSkeleton) add(token string) error {
+	invalid := fmt.Errorf("%w: `%s`", ErrInvalidSkeleton, token)
+
+	if long, ok := conciseStems[token]; ok {
+		token = long
+	}
+
+	stem, option := token, ""
+	if token[0] != '.' && token[0] != '@' {
+		if i := strings.IndexByte(token, '/'); i != -1 {
+			stem, option = token[:i], token[i+1:]
+
+			if stem == "" || option == "" {
+				return invalid
+			}
+		}
+	}
+
+	// stems requiring an option
+	switch stem {
+	case "currency":
+		if len(option) != 3 || strings.ToUpper(option) != option {
+			return invalid
+		}
+		x.style, x.currency = "currency", option
+		return nil
+
+	case "precision-increment":
+		increment, ok := parseDecimal(option)
+		if !ok || increment.neg || increment.isZero() {
+			return invalid
+		}
+		x.precision = func(p *numberPattern) {
+			p.increment = &increment
+			p.minSig, p.maxSig = 0, 0
+		}
+		return nil
+
+	case "integer-width":
+		return x.addIntegerWidth(option, invalid)
+
+	case "unit", "measure-unit":
+		if stem == "measure-unit" {
+			// the type of the unit is not needed, i.e. "length-kilometer"
+			i := strings.IndexByte(option, '-')
+			if i == -1 || !measureTypes[option[:i]] {
+				return invalid
+			}
+			option = option[i+1:]
+		}
+
+		if !isMeasureUnit(option) {
+			return invalid
+		}
+		x.style, x.currency, x.unit = "decimal", "", option
+		return nil
+
+	case "scale":
+		scale, ok := parseDecimal(option)
+		if !ok {
+			return invalid
+		}
+		x.options = append(x.options, func(p *numberPattern) {
+			p.scale = &scale
+		})
+		return nil
+	}
+
+	if option != "" {
+		return invalid
+	}
+
+	switch {
+	case stem == "%x100":
+		x.style = "percent"
+		x.options = append(x.options, func(p *numberPattern) {
+			p.multiplier = 2
+		})
+
+	case stem == "compact-short", stem == "compact-long", stem == "scientific", stem == "engineering":
+		x.notation = stem
+
+	case stem == "notation-simple":
+		x.notation = ""
+
+	case stem == "percent", stem == "permille":
+		x.style = stem
+
+	case stem == "base-unit":
+		x.style, x.currency, x.unit = "decimal", "", ""
+
+	case stem == "precision-integer":
+		x.precision = func(p *numberPattern) {
+			p.minFrac, p.maxFrac = 0, 0
+		}
+
+	case stem == "precision-unlimited":
+		x.precision = func(p *numberPattern) {
+			p.minFrac, p.maxFrac = 0, -1
+			p.minSig, p.maxSig = 0, -1
+		}
+
+	case stem == "precision-currency-standard", stem == "precision-currency-cash":
+		x.precision = nil
+		x.currencyPrecision = true
+
+	case stem[0] == '.':
+		minFrac, maxFrac, ok := readDigitsStem(stem[1:], '0', '#')
+		if !ok {
+			return invalid
+		}
+		x.precision = func(p *numberPattern) {
+			p.minFrac, p.maxFrac = minFrac, maxFrac
+		}
+
+	case stem[0] == '@':
+		minSig, maxSig, ok := readDigitsStem(stem, '@', '#')
+		if !ok {
+			return invalid
+		}
+		x.precision = func(p *numberPattern) {
+			p.minSig, p.maxSig = minSig, maxSig
+			p.minFrac, p.maxFrac = 0, -1
+		}
+
+	case strings.HasPrefix(stem, "rounding-mode-"):
+		mode, ok := roundingModes[stem[len("rounding-mode-"):]]
+		if !ok {
+			return invalid
+		}
+		x.options = append(x.options, func(p *numberPattern) {
+			p.rounding = mode
+		})
+
+	case strings.HasPrefix(stem, "group-"):
+		return x.addGrouping(stem[len("group-"):], invalid)
+
+	case strings.HasPrefix(stem, "sign-"):
+		sign := stem[len("sign-"):]
+		switch sign {
+		default:
+			return invalid
+
+		case "auto", "always", "never", "except-zero", "accounting", "accounting-always", "accounting-except-zero", "negative":
+		}
+		x.options = append(x.options, func(p *numberPattern) {
+			p.sign = sign
+		})
+
+	case strings.HasPrefix(stem, "unit-width-"):
+		display := stem[len("unit-width-"):]
+		switch display {
+		default:
+			return invalid
+
+		case "short":
+			display = ""
+
+		case "narrow", "iso-code", "hidden":
+
+		case "full-name":
+			x.fullName = true
+		}
+		x.options = append(x.options, func(p *numberPattern) {
+			p.currencyDisplay = display
+		})
+
+	case stem == "decimal-auto", stem == "decimal-always":
+		always := stem == "decimal-always"
+		x.options = append(x.options, func(p *numberPattern) {
+			p.decimalAlways = always
+		})
+
+	case stem == "integer-width-trunc":
+		x.options = append(x.options, func(p *numberPattern) {
+			p.minInt, p.maxInt = 0, 0
+		})
+
+	default:
+		return invalid
+	}
+	return nil
+}
+
+// addIntegerWidth interprets the option of an "integer-width" stem (i.e. "*000", "##0").
+func (x *numberSkeleton) addIntegerWidth(option string, invalid error) error {
+	unlimited := strings.HasPrefix(option, "*") || strings.HasPrefix(option, "+")
+	if unlimited {
+		option = option[1:]
+	}
+
+	optional := len(option) - len(strings.TrimLeft(option, "#"))
+	minInt := len(option) - optional
+	if option == "" || strings.Trim(option[optional:], "0") != "" || (unlimited && optional != 0) {
+		return invalid
+	}
+
+	maxInt := len(option)
+	if unlimited {
+		maxInt = -1
+	}
+
+	x.options = append(x.options, func(p *numberPattern) {
+		p.minInt, p.maxInt = minInt, maxInt
+	})
+	return nil
+}
+
+// addGrouping interprets a "group-*" stem.
+func (x *numberSkeleton) addGrouping(strategy string, invalid error) error {
+	var fn func(*numberPattern)
+
+	switch strategy {
+	default:
+		return invalid
+
+	case "off":
+		fn = func(p *numberPattern) {
+			p.grouping, p.grouping2 = 0, 0
+		}
+
+	case "min2":
+		fn = func(p *numberPattern) {
+			p.minGrouping = 2
+		}
+
+	case "auto":
+		fn = func(p *numberPattern) {
+			p.minGrouping = 0
+		}
+
+	case "on-aligned":
+		fn = func(p *numberPattern) {
+			p.minGrouping = 1
+		}
+
+	case "thousands":
+		fn = func(p *numberPattern) {
+			p.grouping, p.grouping2, p.minGrouping = 3, 3, 1
+		}
+	}
+
+	x.options = append(x.options, fn)
+	return nil
+}
+
+// readDigitsStem reads a precision stem (i.e. "00##", "@@#", "0*", "@+") and returns its minimum and
+// maximum number of digits, the maximum being -1 when unlimited.
+func readDigitsStem(stem string, required, optional byte) (int, int, bool) {
+	n := len(stem)
+	min := n - len(strings.TrimLeft(stem, string(required)))
+	rest := stem[min:]
+
+	if rest == "*" || rest == "+" {
+		return min, -1, true
+	} else if strings.Trim(rest, string(optional)) != "" || (required == '@' && min == 0) {
+		return 0, 0, false
+	}
+	return min, n, true
+}
+
+// resolve returns the pattern to use to format a number in the culture described by the given symbols.
+func (x *numberSkeleton) resolve(symbols *numberSymbols) *numberPattern {
+	result := *symbols.patterns[x.style]
+
+	// units do not scale the value, "scale/100" must be used for that
+	result.multiplier = 0
+	result.currency = x.currency
+	result.notation = x.notation
+
+	switch {
+	case x.precision != nil:
+		result.minFrac, result.maxFrac = 0, -1
+		x.precision(&result)
+
+	case x.style == "currency" || x.currencyPrecision:
+		currency := x.currency
+		if currency == "" {
+			currency = symbols.currency
+		}
+		result = *result.withCurrencyDigits(currency)
+
+	case strings.HasPrefix(x.notation, "compact-"):
+		result.minFrac, result.maxFrac = 0, -1
+
+	default:
+		result.minFrac, result.maxFrac = 0, 6
+	}
+
+	for _, fn := range x.options {
+		fn(&result)
+	}
+	return &result
+}
@@ -0,0 +1,110 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestNumberSkeleton(t *testing.T) {
+	doTest(t, Test{
+		"{N, number, ::compact-short currency/EUR precision-integer}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234567}, "€1M"},
+			{map[string]interface{}{"N": 12}, "€12"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, ::compact-short}|{N, number, ::K}|{N, number, ::compact-long}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234}, "1.2K|1.2K|1.2 thousand"},
+			{map[string]interface{}{"N": 12345}, "12K|12K|12 thousand"},
+			{map[string]interface{}{"N": 999999}, "1M|1M|1 million"},
+			{map[string]interface{}{"N": -1500000000}, "-1.5B|-1.5B|-1.5 billion"},
+			{map[string]interface{}{"N": 999}, "999|999|999"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, ::scientific}|{N, number, ::engineering .00}|{N, number, ::E0 @@}",
+		[]Expectation{
+			{map[string]interface{}{"N": 12345}, "1.2345E4|12.34E3|1.2E4"},
+			{map[string]interface{}{"N": 0.00042}, "4.2E-4|420.00E-6|4.2E-4"},
+			{map[string]interface{}{"N": 9.99}, "9.99E0|9.99E0|1.0E1"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, ::.00}|{N, number, ::.0#}|{N, number, ::.0*}|{N, number, ::@@@}|{N, number, ::@@#}|{N, number, ::precision-unlimited}",
+		[]Expectation{
+			{map[string]interface{}{"N": 3.14159}, "3.14|3.14|3.14159|3.14|3.14|3.14159"},
+			{map[string]interface{}{"N": 1}, "1.00|1.0|1.0|1.00|1.0|1"},
+			{map[string]interface{}{"N": 12345.6789}, "12,345.68|12,345.68|12,345.6789|12,300|12,300|12,345.6789"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, ::precision-increment/0.05}|{N, number, ::. rounding-mode-ceiling}|{N, number, ::. rounding-mode-floor}|{N, number, ::. rounding-mode-half-up}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1.23}, "1.25|2|1|1"},
+			{map[string]interface{}{"N": -2.5}, "-2.50|-2|-3|-3"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, ::sign-always}|{N, number, ::+?}|{N, number, ::sign-never}|{N, number, ::currency/USD sign-accounting}",
+		[]Expectation{
+			{map[string]interface{}{"N": 5}, "+5|+5|5|$5.00"},
+			{map[string]interface{}{"N": 0}, "+0|0|0|$0.00"},
+			{map[string]interface{}{"N": -5}, "-5|-5|5|($5.00)"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, ::group-off}|{N, number, ::,?}|{N, number, ::integer-width/*000}|{N, number, ::integer-width/##0}|{N, number, ::scale/100 percent}|{N, number, ::%x100}|{N, number, ::percent}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234}, "1234|1234|1,234|234|123,400%|123,400%|1,234%"},
+			{map[string]interface{}{"N": 0.5}, "0.5|0.5|000.5|0.5|50%|50%|0.5%"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, ::currency/JPY}|{N, number, ::currency/EUR unit-width-iso-code}|{N, number, ::currency/CAD unit-width-narrow}|{N, number, ::currency/EUR unit-width-hidden}|{N, number, ::precision-integer decimal-always}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234.5}, "¥1,234|EUR1,234.50|$1,234.50|1,234.50|1,234."},
+		},
+	})
+}
+
+func TestNumberSkeletonCulture(t *testing.T) {
+	doTestWithCulture(t, "fr", Test{
+		"{N, number, ::compact-long}|{N, number, ::compact-short}|{N, number, ::currency/USD}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1200000}, "1,2 million|1,2\u00a0M|1\u202f200\u202f000,00\u00a0$US"},
+			{map[string]interface{}{"N": 2000000}, "2 millions|2\u00a0M|2\u202f000\u202f000,00\u00a0$US"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{N, number, ::compact-short}|{N, number, ::compact-long}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234}, "1234|1,2 Tausend"},
+			{map[string]interface{}{"N": 3400000}, "3,4\u00a0Mio.|3,4 Millionen"},
+		},
+	})
+}
+
+func TestNumberSkeletonParseException(t *testing.T) {
+	doTestParseException(t, "{N, number, ::compact}", "ParseError: `InvalidSkeleton: `compact`` at 21")
+	doTestParseException(t, "{N, number, ::currency/euro}", "ParseError: `InvalidSkeleton: `currency/euro`` at 27")
+	doTestParseException(t, "{N, number, ::.0#0}", "ParseError: `InvalidSkeleton: `.0#0`` at 18")
+	doTestParseException(t, "{N, number, ::#@@}", "ParseError: `InvalidSkeleton: `#@@`` at 17")
+	doTestParseException(t, "{N, number, ::precision-increment/-1}", "ParseError: `InvalidSkeleton: `precision-increment/-1`` at 36")
+	doTestParseException(t, "{N, number, ::rounding-mode-random}", "ParseError: `InvalidSkeleton: `rounding-mode-random`` at 34")
+	doTestParseException(t, "{N, number, ::sign-always/x}", "ParseError: `InvalidSkeleton: `sign-always/x`` at 27")
+	doTestParseException(t, "{N, number, ::currency/EUR unit-width-full-name}", "ParseError: `InvalidSkeleton: `unit-width-full-name`` at 47")
+	doTestParseException(t, "{N, number, ::unit-width-full-name currency/EUR}", "ParseError: `InvalidSkeleton: `unit-width-full-name`` at 47")
+	doTestParseException(t, "{N, number, ::/}", "ParseError: `InvalidSkeleton: `/`` at 15")
+	doTestParseException(t, "{N, number, ::currency/}", "ParseError: `InvalidSkeleton: `currency/`` at 23")
+	doTestParseException(t, "{N, number, ::/EUR}", "ParseError: `InvalidSkeleton: `/EUR`` at 18")
+	doTestParseException(t, "{N, number, ::sign-always/}", "ParseError: `InvalidSkeleton: `sign-always/`` at 26")
+}
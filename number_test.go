@@ -0,0 +1,123 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestNumber(t *testing.T) {
+	doTest(t, Test{
+		"{N, number}",
+		[]Expectation{
+			{map[string]interface{}{"N": 0}, "0"},
+			{map[string]interface{}{"N": -5}, "-5"},
+			{map[string]interface{}{"N": 1234}, "1,234"},
+			{map[string]interface{}{"N": 1234567.891}, "1,234,567.891"},
+			{map[string]interface{}{"N": 0.12345}, "0.123"},
+			{map[string]interface{}{"N": "9876543210.5"}, "9,876,543,210.5"},
+			{map[string]interface{}{"N": uint64(18446744073709551615)}, "18,446,744,073,709,551,615"},
+			{nil, ""},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, integer} items",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234.5}, "1,234 items"},
+			{map[string]interface{}{"N": 1235.5}, "1,236 items"},
+			{map[string]interface{}{"N": "-0.4"}, "-0 items"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, percent}",
+		[]Expectation{
+			{map[string]interface{}{"N": 0.25}, "25%"},
+			{map[string]interface{}{"N": 12.345}, "1,234%"},
+		},
+	})
+
+	doTest(t, Test{
+		"Total: {N, number, currency}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234.5}, "Total: $1,234.50"},
+			{map[string]interface{}{"N": -3}, "Total: -$3.00"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, number, #,##0.00}|{N, number, 000}|{N, number, #,##,##0}|{N, number, '#'0.#;(0.#)}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234567.5}, "1,234,567.50|1234568|12,34,568|#1234567.5"},
+			{map[string]interface{}{"N": -7}, "-7.00|-007|-7|(7)"},
+		},
+	})
+
+	doTestException(
+		t,
+		"{N, number}",
+		map[string]interface{}{"N": struct{}{}},
+		"Number: Unsupported type: struct {}",
+	)
+
+	doTestException(
+		t,
+		"{N, number}",
+		map[string]interface{}{"N": "twelve"},
+		"Number: Invalid value: `twelve`",
+	)
+
+	doTestException(
+		t,
+		"{N, number}",
+		map[string]interface{}{"N": "1e999999999"},
+		"Number: Invalid value: `1e999999999`",
+	)
+}
+
+func TestNumberCulture(t *testing.T) {
+	doTestWithCulture(t, "fr", Test{
+		"{N, number} {N, number, percent} {N, number, currency}",
+		[]Expectation{
+			{map[string]interface{}{"N": 12345.678}, "12 345,678 1 234 568 % 12 345,68 €"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{N, number, #,##0.00}",
+		[]Expectation{
+			{map[string]interface{}{"N": 12345.678}, "12.345,68"},
+		},
+	})
+
+	doTestWithCulture(t, "es", Test{
+		"{N, number}|{M, number}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234, "M": 12345}, "1234|12.345"},
+		},
+	})
+
+	// unknown cultures fall back to their language, then to "en"
+	doTestWithCulture(t, "pt-PT", Test{
+		"{N, number}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234.5}, "1,234.5"},
+		},
+	})
+}
+
+func TestNumberParseException(t *testing.T) {
+	doTestParseException(t, "{N, number, }", "ParseError: `MissingStyle` at 12")
+	doTestParseException(t, "{N, number, short}", "ParseError: `InvalidStyle: `short`` at 17")
+	doTestParseException(t, "{N, number, #.#.#}", "ParseError: `InvalidStyle: `#.#.#`` at 17")
+	doTestParseException(t, "{N, number, {#}}", "ParseError: `InvalidExpr` at 12")
+	doTestParseException(t, "{N, number, #", "ParseError: `UnbalancedBraces` at 13")
+}
+
+func BenchmarkNumber(b *testing.B) {
+	doBenchmarkExecute(
+		b,
+		"This is a {A, number, currency}",
+		"This is a $1,234.50",
+		map[string]interface{}{"A": 1234.5},
+	)
+}
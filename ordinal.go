@@ -1,9 +1,9 @@
 package messageformat
 
 import (
-	"bytes"
 	"fmt"
 	"strconv"
+	"strings"
 )
 
 // formatOrdinal is the format function associated with the "selectordinal" type.
@@ -15,20 +15,26 @@ import (
 // It will falls back to the "other" choice if :
 // - its key can't be found in the given map
 // - the computed named key (MessageFormat.getNamedKey) is not a key of the given map
-func formatOrdinal(expr Expression, ptr_output *bytes.Buffer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+func formatOrdinal(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
 	o := expr.(*selectExpr)
 
-	value, err := toString(*data, o.key)
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	}
+
+	value, err := valueToString(v)
 	if err != nil {
 		return err
 	}
 
 	var choice *node
+	var key string
 
-	if v, ok := (*data)[o.key]; ok {
+	if ok {
 		switch t := v.(type) {
 		default:
-			return fmt.Errorf("Ordinal: Unsupported type for named key: %T", v)
+			return fmt.Errorf("Ordinal: %w for named key: %T", ErrUnsupportedType, v)
 
 		case int, float64:
 
@@ -39,7 +45,7 @@ func formatOrdinal(expr Expression, ptr_output *bytes.Buffer, data *map[string]i
 			}
 		}
 
-		key, err := ptr_mf.getNamedKey(v, true)
+		key, err = ptr_mf.getNamedKey(v, true)
 		if err != nil {
 			return err
 		}
@@ -47,7 +53,25 @@ func formatOrdinal(expr Expression, ptr_output *bytes.Buffer, data *map[string]i
 	}
 
 	if choice == nil {
-		choice = o.choices["other"]
+		key = "other"
+		choice = o.choices[key]
+	}
+	return withChoice(choice.format(ptr_output, data, ptr_mf, ptr_mf.formatPound(value)), o.key, key)
+}
+
+// parseDigitsOrdinal is the parse function associated with the "ordinal" type.
+//
+// The number is written with the ordinal suffix of the culture (i.e. "22nd", "3e", "1.º"), the name of another
+// digits ordinal rule set (%digits-ordinal*) may be given as the style.
+func parseDigitsOrdinal(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	expr, pos, err := parseRuleSetExpr(varname, "%digits-ordinal", char, start, end, ptr_input)
+	if err != nil {
+		return nil, pos, err
+	}
+
+	ruleSet := expr.(*spelloutExpr).ruleSet
+	if !strings.HasPrefix(ruleSet, "%digits-ordinal") {
+		return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidStyle, ruleSet)
 	}
-	return choice.format(ptr_output, data, ptr_mf, value)
+	return expr, pos, nil
 }
@@ -18,6 +18,13 @@ func TestSelectOrdinal(t *testing.T) {
 		},
 	})
 
+	doTestWithCulture(t, "de", Test{
+		"{N, selectordinal, other{#.}}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1001}, "1.001."},
+		},
+	})
+
 	doTestException(
 		t,
 		"{VAR,selectordinal,other{succeed}}",
@@ -26,6 +33,67 @@ func TestSelectOrdinal(t *testing.T) {
 	)
 }
 
+func TestOrdinal(t *testing.T) {
+	doTest(t, Test{
+		"{N, ordinal}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1}, "1st"},
+			{map[string]interface{}{"N": 2}, "2nd"},
+			{map[string]interface{}{"N": 3}, "3rd"},
+			{map[string]interface{}{"N": 11}, "11th"},
+			{map[string]interface{}{"N": 22}, "22nd"},
+			{map[string]interface{}{"N": 1001}, "1,001st"},
+			{map[string]interface{}{"N": "104"}, "104th"},
+			{map[string]interface{}{"N": -3}, "-3rd"},
+			{nil, ""},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, ordinal, %digits-ordinal}",
+		[]Expectation{
+			{map[string]interface{}{"N": 3}, "3rd"},
+		},
+	})
+
+	doTestWithCulture(t, "fr", Test{
+		"{N, ordinal}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1}, "1er"},
+			{map[string]interface{}{"N": 3}, "3e"},
+		},
+	})
+
+	doTestWithCulture(t, "es", Test{
+		"{N, ordinal}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1}, "1.º"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{N, ordinal}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1234}, "1.234."},
+		},
+	})
+
+	// the "en" rules are used with the "en" plural rules for a culture without rules
+	for _, culture := range []string{"ja", "ru"} {
+		doTestWithCulture(t, culture, Test{
+			"{N, ordinal}|{N, spellout}",
+			[]Expectation{
+				{map[string]interface{}{"N": 1}, "1st|one"},
+				{map[string]interface{}{"N": 22}, "22nd|twenty-two"},
+			},
+		})
+	}
+
+	doTestParseException(t, "{N, ordinal, th}", "ParseError: `InvalidStyle: `th`` at 15")
+	doTestParseException(t, "{N, ordinal, %spellout-cardinal}", "ParseError: `InvalidStyle: `%spellout-cardinal`` at 31")
+	doTestParseException(t, "{N, ordinal, %spellout-ordinal}", "ParseError: `InvalidStyle: `%spellout-ordinal`` at 30")
+}
+
 func BenchmarkSelectOrdinal(b *testing.B) {
 	doBenchmarkExecute(
 		b,
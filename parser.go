@@ -1,9 +1,10 @@
 package messageformat
 
 import (
-	"bytes"
+	"errors"
 	"fmt"
 	"github.com/gotnospirit/makeplural/plural"
+	"io"
 )
 
 const (
@@ -12,13 +13,63 @@ const (
 	CloseChar  = '}'
 	PartChar   = ','
 	PoundChar  = '#'
+	QuoteChar  = '\''
+	PathChar   = '.'
+)
+
+// An ApostropheMode tells how the syntax characters of a message are escaped.
+type ApostropheMode int
+
+const (
+	// EscapeBackslash escapes a single syntax character with a preceding backslash (i.e. "\{").
+	EscapeBackslash ApostropheMode = iota
+	// ApostropheDoubleOptional follows the ICU DOUBLE_OPTIONAL mode: an apostrophe starts a quoted literal text only
+	// when it precedes a syntax character (i.e. "'{'", "'{literal text}'"), a doubled apostrophe ("''") always
+	// renders one apostrophe and any other apostrophe is rendered as is. The braces are syntax characters in any
+	// message, a "#" only is in a choice of a "plural" or "selectordinal" argument and a "|" in a choice of a
+	// "choice" argument.
+	ApostropheDoubleOptional
+)
+
+// A NameMode tells which chars an argument name can hold.
+type NameMode int
+
+const (
+	// StrictNames only accepts the [_0-9a-zA-Z] chars.
+	StrictNames NameMode = iota
+	// UnicodeNames accepts the Unicode identifier chars (UAX #31) and hyphens (i.e. "{件数}", "{first-name}").
+	UnicodeNames
+)
+
+// A MissingMode tells how an argument which is not in the formatted data is rendered.
+type MissingMode int
+
+const (
+	// MissingEmpty renders a missing argument as an empty string, and selects the "other" choice of a missing
+	// "select", "plural" or "selectordinal" argument.
+	MissingEmpty MissingMode = iota
+	// MissingError fails the formatting with a FormatError whose code is ErrMissingArgument.
+	MissingError
+	// MissingPlaceholder renders a missing argument, whatever its type, as its name between braces (i.e. "{name}").
+	MissingPlaceholder
 )
 
 type (
 	// parseFunc describes a function used to parse a subset of the input string into an expression.
 	parseFunc func(string, *Parser, rune, int, int, *[]rune) (Expression, int, error)
-	// formatFunc describes a function used to format an expression into the output buffer.
-	formatFunc func(Expression, *bytes.Buffer, *map[string]interface{}, *MessageFormat, string) error
+	// formatFunc describes a function used to format an expression into the output writer.
+	formatFunc func(Expression, Writer, *map[string]interface{}, *MessageFormat, string) error
+
+	// A Writer is the output of the format functions (i.e. *bytes.Buffer, *strings.Builder, *bufio.Writer).
+	//
+	// The format functions don't check its write errors: FormatTo returns the first one once the message is
+	// formatted.
+	Writer interface {
+		io.Writer
+		io.ByteWriter
+		io.StringWriter
+		WriteRune(rune) (int, error)
+	}
 	// pluralFunc describes a function used to produce a named key when processing a plural or selectordinal expression.
 	pluralFunc func(interface{}, bool) string
 
@@ -26,6 +77,18 @@ type (
 		parsers    map[string]parseFunc
 		formatters map[string]formatFunc
 		plural     pluralFunc
+		culture    string
+		apostrophe ApostropheMode
+		names      NameMode
+		missing    MissingMode
+
+		diagnostics *diagnostics // errors of ParseAll, nil when parsing stops at the first error
+	}
+
+	// diagnostics collects the errors reported while parsing an input.
+	diagnostics struct {
+		errors []*ParseError
+		halted bool // true once the remaining input is skipped
 	}
 )
 
@@ -37,14 +100,46 @@ func (x *Parser) Parse(input string) (*MessageFormat, error) {
 	for pos < end {
 		i, level, err := x.parse(pos, end, &runes, &root)
 		if err != nil {
-			return nil, parseError{err.Error(), i}
+			return nil, newParseError(err, i, runes)
 		} else if level != 0 {
-			return nil, parseError{"UnbalancedBraces", i}
+			return nil, newParseError(ErrUnbalancedBraces, i, runes)
 		}
 
 		pos = i
 	}
-	return &MessageFormat{root, x.formatters, x.plural}, nil
+	return x.newMessageFormat(root, runes), nil
+}
+
+// ParseAll parses the input without stopping at the first error.
+//
+// Each erroneous argument is reported then skipped up to its closing brace, so that the errors of the other
+// arguments (i.e. in the other choices of a "select") are reported too. The returned MessageFormat is never
+// nil: it is made of the valid parts of the input.
+func (x *Parser) ParseAll(input string) (*MessageFormat, []*ParseError) {
+	runes := []rune(input)
+	pos, end := 0, len(runes)
+
+	// a copy, so that the parser can be used concurrently
+	p := *x
+	p.diagnostics = new(diagnostics)
+
+	root := node{}
+	for pos < end {
+		i, level, _ := p.parse(pos, end, &runes, &root)
+		if level != 0 {
+			// an unexpected closing brace
+			p.diagnostics.report(ErrUnbalancedBraces, i, &runes)
+			i++
+		}
+
+		pos = i
+	}
+	return x.newMessageFormat(root, runes), p.diagnostics.errors
+}
+
+func (x *Parser) newMessageFormat(root node, runes []rune) *MessageFormat {
+	root.start, root.end = 0, len(runes)
+	return &MessageFormat{root: root, source: runes, apostrophe: x.apostrophe, formatters: x.formatters, plural: x.plural, culture: x.culture, missing: x.missing}
 }
 
 func (x *Parser) Register(key string, p parseFunc, f formatFunc) error {
@@ -56,35 +151,74 @@ func (x *Parser) Register(key string, p parseFunc, f formatFunc) error {
 	return nil
 }
 
+// SetApostropheMode selects how the syntax characters of the next parsed messages are escaped.
+//
+// The default mode is EscapeBackslash.
+func (x *Parser) SetApostropheMode(mode ApostropheMode) error {
+	if mode != EscapeBackslash && mode != ApostropheDoubleOptional {
+		return fmt.Errorf("UnknownApostropheMode")
+	}
+	x.apostrophe = mode
+	return nil
+}
+
+// SetNameMode selects which chars the argument names of the next parsed messages can hold.
+//
+// The default mode is StrictNames.
+func (x *Parser) SetNameMode(mode NameMode) error {
+	if mode != StrictNames && mode != UnicodeNames {
+		return fmt.Errorf("UnknownNameMode")
+	}
+	x.names = mode
+	return nil
+}
+
+// SetMissingMode selects how the missing arguments of the next parsed messages are rendered, unless a formatting
+// call overrides it (see WithMissingMode).
+//
+// The default mode is MissingEmpty.
+func (x *Parser) SetMissingMode(mode MissingMode) error {
+	if mode != MissingEmpty && mode != MissingError && mode != MissingPlaceholder {
+		return fmt.Errorf("UnknownMissingMode")
+	}
+	x.missing = mode
+	return nil
+}
+
 func (x *Parser) parseExpression(start, end int, ptr_input *[]rune) (string, Expression, int, error) {
-	varname, char, pos, err := readVar(start, end, ptr_input)
+	varname, char, pos, err := readVar(start, end, ptr_input, x.names == UnicodeNames)
 	if err != nil {
 		return "", nil, pos, err
 	} else if varname == "" {
-		return "", nil, pos, fmt.Errorf("MissingVarName")
+		return "", nil, pos, ErrMissingVarName
 	} else if char == CloseChar {
 		return "var", varname, pos, nil
 	}
 
-	ctype, char, pos, err := readVar(pos+1, end, ptr_input)
+	ctype, char, pos, err := readVar(pos+1, end, ptr_input, false)
 	if err != nil {
-		return "", nil, pos, err
+		return "", nil, pos, argumentError{varname, err}
 	}
 
 	fn, ok := x.parsers[ctype]
 	if !ok {
-		return "", nil, pos, fmt.Errorf("UnknownType: `%s`", ctype)
+		return "", nil, pos, argumentError{varname, fmt.Errorf("%w: `%s`", ErrUnknownType, ctype)}
 	} else if fn == nil {
-		return "", nil, pos, fmt.Errorf("UndefinedParseFunc: `%s`", ctype)
+		return "", nil, pos, argumentError{varname, fmt.Errorf("%w: `%s`", ErrUndefinedParseFunc, ctype)}
 	}
 
 	expr, pos, err := fn(varname, x, char, pos, end, ptr_input)
 	if err != nil {
+		// keeps the name of the innermost argument
+		var argument argumentError
+		if !errors.As(err, &argument) {
+			err = argumentError{varname, err}
+		}
 		return "", nil, pos, err
 	}
 
 	if pos >= end || (*ptr_input)[pos] != CloseChar {
-		return "", nil, pos, fmt.Errorf("UnbalancedBraces")
+		return "", nil, pos, argumentError{varname, ErrUnbalancedBraces}
 	}
 	return ctype, expr, pos, nil
 }
@@ -95,6 +229,8 @@ func (x *Parser) parse(start, end int, ptr_input *[]rune, parent *node) (int, in
 	escaped := false
 	input := *ptr_input
 
+	parent.start = start
+
 loop:
 	for pos < end {
 		char := input[pos]
@@ -106,7 +242,11 @@ loop:
 
 		case EscapeChar:
 			pos++
-			escaped = true
+			escaped = x.apostrophe == EscapeBackslash
+
+		case QuoteChar:
+			pos = x.skipQuote(pos, end, ptr_input, parent.parent)
+			escaped = false
 
 		case CloseChar:
 			if !escaped {
@@ -121,15 +261,25 @@ loop:
 				level++
 
 				if pos > start {
-					parent.add("literal", parseLiteral(start, pos, ptr_input))
+					parent.add("literal", x.parseLiteral(start, pos, ptr_input, parent.parent), start, pos)
 				}
 
 				ctype, child, i, err := x.parseExpression(pos+1, end, ptr_input)
 				if err != nil {
-					return i, level, err
-				}
+					if x.diagnostics == nil {
+						return i, level, err
+					}
 
-				parent.add(ctype, child)
+					// skips the whole expression, or the rest of the input when it can't be found
+					x.diagnostics.report(err, i, ptr_input)
+					if i = x.skipExpression(pos, end, ptr_input); i == -1 {
+						x.diagnostics.halted = true
+						parent.end = end
+						return end, 0, nil
+					}
+				} else {
+					parent.add(ctype, child, pos, i+1)
+				}
 
 				level--
 
@@ -143,11 +293,93 @@ loop:
 	}
 
 	if pos > start {
-		parent.add("literal", parseLiteral(start, pos, ptr_input))
+		parent.add("literal", x.parseLiteral(start, pos, ptr_input, parent.parent), start, pos)
 	}
+	parent.end = pos
 	return pos, level, nil
 }
 
+func (x *diagnostics) report(err error, offset int, ptr_input *[]rune) {
+	if !x.halted {
+		x.errors = append(x.errors, newParseError(err, offset, *ptr_input))
+	}
+}
+
+// skipExpression returns the position of the brace closing the expression opened at the given position,
+// or -1 if there is none.
+func (x *Parser) skipExpression(start, end int, ptr_input *[]rune) int {
+	input := *ptr_input
+	level := 0
+	escaped := false
+
+	for pos := start; pos < end; pos++ {
+		char := input[pos]
+
+		if escaped {
+			escaped = false
+			continue
+		}
+
+		switch char {
+		case EscapeChar:
+			escaped = x.apostrophe == EscapeBackslash
+
+		case QuoteChar:
+			// the type of the argument is unknown: only the braces are quoted
+			pos = x.skipQuote(pos, end, ptr_input, "") - 1
+
+		case OpenChar:
+			level++
+
+		case CloseChar:
+			level--
+			if level == 0 {
+				return pos
+			}
+		}
+	}
+	return -1
+}
+
+// parseLiteral parses a literal text according to the apostrophe mode.
+func (x *Parser) parseLiteral(start, end int, ptr_input *[]rune, parent string) []string {
+	if x.apostrophe == ApostropheDoubleOptional {
+		return parseQuotedLiteral(start, end, ptr_input, parent)
+	}
+	return parseLiteral(start, end, ptr_input)
+}
+
+// skipQuote returns the position following the apostrophe read at the given position and, in the
+// ApostropheDoubleOptional mode, the quoted text it may start in a message held by the given type of argument.
+func (x *Parser) skipQuote(start, end int, ptr_input *[]rune, parent string) int {
+	if x.apostrophe != ApostropheDoubleOptional {
+		return start + 1
+	}
+
+	input := *ptr_input
+
+	pos := start + 1
+	if pos >= end {
+		return pos
+	} else if input[pos] == QuoteChar {
+		return pos + 1
+	} else if !isSyntaxChar(input[pos], parent) {
+		return pos
+	}
+
+	for pos < end {
+		if input[pos] == QuoteChar {
+			if pos+1 < end && input[pos+1] == QuoteChar {
+				pos += 2
+				continue
+			}
+			return pos + 1
+		}
+		pos++
+	}
+	return pos
+}
+
 func NewWithCulture(name string) (*Parser, error) {
 	fn, err := plural.GetFunc(name)
 	if err != nil {
@@ -159,12 +391,23 @@ func NewWithCulture(name string) (*Parser, error) {
 	result.parsers = make(map[string]parseFunc)
 	result.formatters = make(map[string]formatFunc)
 	result.plural = fn
+	result.culture = name
 
 	result.Register("literal", nil, formatLiteral)
 	result.Register("var", nil, formatVar)
 	result.Register("select", parseSelect, formatSelect)
-	result.Register("selectordinal", parseSelect, formatOrdinal)
+	result.Register("selectordinal", parseOrdinal, formatOrdinal)
 	result.Register("plural", parsePlural, formatPlural)
+	result.Register("choice", parseChoice, formatChoice)
+	result.Register("number", parseNumber, formatNumber)
+	result.Register("date", parseDate, formatDate)
+	result.Register("time", parseTime, formatDate)
+	result.Register("spellout", parseSpellout, formatSpellout)
+	result.Register("ordinal", parseDigitsOrdinal, formatSpellout)
+	result.Register("duration", parseDuration, formatDuration)
+	result.Register("relativetime", parseRelativeTime, formatRelativeTime)
+	result.Register("list", parseList, formatList)
+	result.Register("unit", parseUnit, formatUnit)
 	return result, nil
 }
 
@@ -3,6 +3,7 @@
 package messageformat
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 )
@@ -18,23 +19,35 @@ type Expectation struct {
 }
 
 func doTest(t *testing.T, data Test) {
-	if o, err := New(); err != nil {
+	doTestWithCulture(t, "en", data)
+}
+
+func doTestWithCulture(t *testing.T, culture string, data Test) {
+	doTestWithOptions(t, culture, data)
+}
+
+func doTestWithOptions(t *testing.T, culture string, data Test, options ...FormatOption) {
+	if o, err := NewWithCulture(culture); err != nil {
 		t.Errorf("`%s` threw <%s>", data.input, err)
 	} else {
-		mf, err := o.Parse(data.input)
+		doTestWithParser(t, o, data, options...)
+	}
+}
 
-		if err != nil {
-			t.Errorf("`%s` threw <%s>", data.input, err)
-		} else {
-			for _, ex := range data.expects {
-				result, err := mf.FormatMap(ex.data)
-				if err != nil {
-					t.Errorf("`%s` threw <%s>", data.input, err)
-				} else if result != ex.output {
-					t.Errorf("Expecting <%v> but got <%v>", ex.output, result)
-				} else if testing.Verbose() {
-					fmt.Printf("- Got expected value <%s>\n", result)
-				}
+func doTestWithParser(t *testing.T, o *Parser, data Test, options ...FormatOption) {
+	mf, err := o.Parse(data.input)
+
+	if err != nil {
+		t.Errorf("`%s` threw <%s>", data.input, err)
+	} else {
+		for _, ex := range data.expects {
+			result, err := mf.FormatMap(ex.data, options...)
+			if err != nil {
+				t.Errorf("`%s` threw <%s>", data.input, err)
+			} else if result != ex.output {
+				t.Errorf("Expecting <%v> but got <%v>", ex.output, result)
+			} else if testing.Verbose() {
+				fmt.Printf("- Got expected value <%s>\n", result)
 			}
 		}
 	}
@@ -256,6 +269,72 @@ func BenchmarkEscaped(b *testing.B) {
 	)
 }
 
+func TestApostrophe(t *testing.T) {
+	o, _ := New()
+	if err := o.SetApostropheMode(ApostropheMode(42)); err == nil {
+		t.Errorf("Expecting exception <UnknownApostropheMode> but got none")
+	}
+	o.SetApostropheMode(ApostropheDoubleOptional)
+
+	doTestWithParser(t, o, Test{
+		`'{'`,
+		[]Expectation{
+			{output: `{`},
+		},
+	})
+
+	doTestWithParser(t, o, Test{
+		`I don''t know, I'm {N}`,
+		[]Expectation{
+			{map[string]interface{}{"N": "lost"}, `I don't know, I'm lost`},
+		},
+	})
+
+	doTestWithParser(t, o, Test{
+		`'{literal text}' and '{'{N}'}'`,
+		[]Expectation{
+			{map[string]interface{}{"N": 5}, `{literal text} and {5}`},
+		},
+	})
+
+	doTestWithParser(t, o, Test{
+		`\{N}`,
+		[]Expectation{
+			{map[string]interface{}{"N": 5}, `\5`},
+		},
+	})
+
+	doTestWithParser(t, o, Test{
+		`{N, plural, one{# file ('#')} other{'{#}' files, it''s '#1 '''#''!}}`,
+		[]Expectation{
+			{map[string]interface{}{"N": 1}, `1 file (#)`},
+			{map[string]interface{}{"N": 2}, `{#} files, it's #1 '2'!`},
+		},
+	})
+
+	doTestWithParser(t, o, Test{
+		`{N, choice, 0#none|1#{N}'|'one}`,
+		[]Expectation{
+			{map[string]interface{}{"N": 2}, `2|one`},
+		},
+	})
+
+	// as with ICU, '#' and '|' are only quoted where they are syntax chars
+	doTestWithParser(t, o, Test{
+		`Rank '#1' today, a '|' b {S, select, other{'|' c}} {N, plural, other{'|' d}}`,
+		[]Expectation{
+			{map[string]interface{}{"S": "x", "N": 1}, `Rank '#1' today, a '|' b '|' c '|' d`},
+		},
+	})
+
+	doTestWithParser(t, o, Test{
+		`{N, number, '#'0}`,
+		[]Expectation{
+			{map[string]interface{}{"N": 5}, `#5`},
+		},
+	})
+}
+
 func TestNonAscii(t *testing.T) {
 	doTest(t, Test{
 		`猫 {N}。。。`,
@@ -380,3 +459,51 @@ func TestRegister(t *testing.T) {
 		doTestCompileError(t, input, "UndefinedFormatFunc: `noeval`", err)
 	}
 }
+
+func TestParseAll(t *testing.T) {
+	o, _ := New()
+
+	mf, errs := o.ParseAll("Hi {NAME}, {G, select, male{{N, foo}} female{{N, plural, one{#}}} other{{N, number, }}} {X, date, full}}{}")
+
+	expected := []string{
+		"ParseError: `UnknownType: `foo`` at 35",
+		"ParseError: `MissingMandatoryChoice` at 63",
+		"ParseError: `MissingStyle` at 84",
+		"ParseError: `UnbalancedBraces` at 103",
+		"ParseError: `MissingVarName` at 105",
+	}
+
+	if len(errs) != len(expected) {
+		t.Errorf("Expecting %d errors but got <%v>", len(expected), errs)
+	} else {
+		for i, err := range errs {
+			doTestError(t, expected[i], err)
+		}
+
+		if errs[0].Argument != "N" || errs[3].Argument != "" || !errors.Is(errs[1], ErrMissingMandatoryChoice) {
+			t.Errorf("Unexpected errors <%v>", errs)
+		}
+	}
+
+	// the partial message holds the valid parts
+	if result, err := mf.FormatMap(map[string]interface{}{"NAME": "Bob", "G": "male", "X": 0}); err != nil {
+		t.Errorf("Unexpected error <%s>", err)
+	} else if result != "Hi Bob,  Thursday, January 1, 1970" {
+		t.Errorf("Expecting <%v> but got <%v>", "Hi Bob,  Thursday, January 1, 1970", result)
+	}
+
+	// a missing closing brace is reported once the nested errors are
+	_, errs = o.ParseAll("{A, select, other{{B, foo} {C}{D, foo}")
+	if len(errs) != 3 {
+		t.Errorf("Expecting 3 errors but got <%v>", errs)
+	} else {
+		doTestError(t, "ParseError: `UnknownType: `foo`` at 25", errs[0])
+		doTestError(t, "ParseError: `UnknownType: `foo`` at 37", errs[1])
+		doTestError(t, "ParseError: `UnbalancedBraces` at 39", errs[2])
+	}
+
+	_, errs = o.ParseAll("{A, select, other{{B}}}")
+	if len(errs) != 0 {
+		t.Errorf("Expecting no error but got <%v>", errs)
+	}
+}
@@ -0,0 +1,86 @@
+package messageformat
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// lookup retrieves the value of an argument from the given map.
+//
+// A key which is not itself in the map but holds a PathChar (i.e. "order.items.0.title") is resolved segment
+// by segment, through the keys of a map, the fields of a struct (named as in FormatStruct) and the indexes of a
+// slice or an array. A nil value along the path resolves to nil.
+//
+// It returns false if the argument (or the first segment of the path) can't be found in the given map, and an
+// error if one of the following segments does not exist.
+func lookup(data map[string]interface{}, key string) (interface{}, bool, error) {
+	if v, ok := data[key]; ok {
+		return v, true, nil
+	}
+
+	segments := strings.Split(key, string(PathChar))
+	if len(segments) == 1 {
+		return nil, false, nil
+	}
+
+	v, ok := data[segments[0]]
+	if !ok {
+		return nil, false, nil
+	}
+
+	for _, segment := range segments[1:] {
+		value := reflect.ValueOf(v)
+		for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+			if value.IsNil() {
+				return nil, true, nil
+			}
+			value = value.Elem()
+		}
+
+		if !value.IsValid() {
+			return nil, true, nil
+		}
+
+		next, ok := resolveSegment(value, segment)
+		if !ok {
+			return nil, true, fmt.Errorf("Path: %w `%s` in `%s`", ErrUnknownSegment, segment, key)
+		}
+		v = next
+	}
+	return v, true, nil
+}
+
+// resolveSegment returns the value of a map key, a struct field or a slice index.
+func resolveSegment(value reflect.Value, segment string) (interface{}, bool) {
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, false
+		}
+
+		item := value.MapIndex(reflect.ValueOf(segment).Convert(value.Type().Key()))
+		if !item.IsValid() {
+			return nil, false
+		}
+		return item.Interface(), true
+
+	case reflect.Struct:
+		fields, err := structToMap(value.Interface())
+		if err != nil {
+			return nil, false
+		}
+
+		result, ok := fields[segment]
+		return result, ok
+
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(segment)
+		if err != nil || i < 0 || i >= value.Len() {
+			return nil, false
+		}
+		return value.Index(i).Interface(), true
+	}
+	return nil, false
+}
@@ -0,0 +1,51 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	size := 2.5
+	data := map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":   "Alice",
+			"gender": "female",
+			"nick":   nil,
+		},
+		"order": &struct {
+			Items []testFolder `mf:"items"`
+			Total int
+		}{
+			Items: []testFolder{{Count: 3, Size: &size, testOwner: &testOwner{Name: "Bob"}}},
+			Total: 1,
+		},
+		"tags":       [2]string{"new", "hot"},
+		"owner.name": "flat",
+		"counts":     map[string]int{"files": 1},
+	}
+
+	doTest(t, Test{
+		"{user.name} ordered {order.Total, plural, one{# item} other{# items}} ({order.items.0.count, number}, {order.items.0.Size}, {order.items.0.Name}, {tags.1}) for {user.gender, select, female{her} other{their}} {owner.name} {counts.files, selectordinal, one{#st} other{#th}}{user.nick.first}",
+		[]Expectation{
+			{data, "Alice ordered 1 item (3, 2.5, Bob, hot) for her flat 1st"},
+			{nil, " ordered # items (, , , ) for their  #th"},
+		},
+	})
+
+	for path, segment := range map[string]string{
+		"user.age":                "age",
+		"order.items.1.count":     "1",
+		"order.items.x":           "x",
+		"tags.2":                  "2",
+		"user.name.first":         "first",
+		"order.Items":             "Items",
+		"order.items.0.testOwner": "testOwner",
+	} {
+		doTestException(t, "{"+path+"}", data, "Path: Unknown segment `"+segment+"` in `"+path+"`")
+	}
+
+	doTestParseException(t, "{.a}", "ParseError: `InvalidFormat` at 1")
+	doTestParseException(t, "{a..b}", "ParseError: `InvalidFormat` at 3")
+	doTestParseException(t, "{a.}", "ParseError: `InvalidFormat` at 2")
+	doTestParseException(t, "{a. b}", "ParseError: `InvalidFormat` at 4")
+}
@@ -13,7 +13,7 @@ type pluralExpr struct {
 
 func parsePlural(varname string, ptr_compiler *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
 	if char != PartChar {
-		return nil, start, fmt.Errorf("MalformedOption")
+		return nil, start, ErrMalformedOption
 	}
 
 	hasOtherChoice := false
@@ -33,7 +33,7 @@ func parsePlural(varname string, ptr_compiler *Parser, char rune, start, end int
 
 		if char == ':' {
 			if key != "offset" {
-				return nil, i, fmt.Errorf("UnsupportedExtension: `%s`", key)
+				return nil, i, fmt.Errorf("%w: `%s`", ErrUnsupportedExtension, key)
 			}
 
 			offset, c, j, err := readOffset(i+1, end, ptr_input)
@@ -52,7 +52,7 @@ func parsePlural(varname string, ptr_compiler *Parser, char rune, start, end int
 			if err != nil {
 				return nil, j, err
 			} else if k == "" {
-				return nil, j, fmt.Errorf("MissingChoiceName")
+				return nil, j, ErrMissingChoiceName
 			}
 
 			key, char, i = k, c, j
@@ -62,7 +62,7 @@ func parsePlural(varname string, ptr_compiler *Parser, char rune, start, end int
 			hasOtherChoice = true
 		}
 
-		choice, c, i, err := readChoice(ptr_compiler, char, i, end, ptr_input)
+		choice, c, i, err := readChoice(ptr_compiler, "plural", char, i, end, ptr_input)
 		if err != nil {
 			return nil, i, err
 		}
@@ -76,7 +76,7 @@ func parsePlural(varname string, ptr_compiler *Parser, char rune, start, end int
 	}
 
 	if !hasOtherChoice {
-		return nil, pos, fmt.Errorf("MissingMandatoryChoice")
+		return nil, pos, ErrMissingMandatoryChoice
 	}
 	return result, pos, nil
 }
@@ -90,22 +90,27 @@ func parsePlural(varname string, ptr_compiler *Parser, char rune, start, end int
 // It will falls back to the "other" choice if :
 // - its key can't be found in the given map
 // - the computed named key (MessageFormat.getNamedKey) is not a key of the given map
-func formatPlural(expr Expression, ptr_output *bytes.Buffer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+func formatPlural(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
 	o := expr.(*pluralExpr)
 	key := o.key
 	offset := o.offset
 
-	value, err := toString(*data, key)
+	v, ok, err := lookup(*data, key)
+	if err != nil {
+		return err
+	}
+
+	value, err := valueToString(v)
 	if err != nil {
 		return err
 	}
 
 	var choice *node
 
-	if v, ok := (*data)[key]; ok {
+	if ok {
 		switch t := v.(type) {
 		default:
-			return fmt.Errorf("Plural: Unsupported type for named key: %T", v)
+			return fmt.Errorf("Plural: %w for named key: %T", ErrUnsupportedType, v)
 
 		case int:
 			key = fmt.Sprintf("=%d", t)
@@ -159,9 +164,10 @@ func formatPlural(expr Expression, ptr_output *bytes.Buffer, data *map[string]in
 	}
 
 	if choice == nil {
-		choice = o.choices["other"]
+		key = "other"
+		choice = o.choices[key]
 	}
-	return choice.format(ptr_output, data, ptr_mf, value)
+	return withChoice(choice.format(ptr_output, data, ptr_mf, ptr_mf.formatPound(value)), o.key, key)
 }
 
 func readOffset(start, end int, ptr_input *[]rune) (int, rune, int, error) {
@@ -184,14 +190,14 @@ func readOffset(start, end int, ptr_input *[]rune) (int, rune, int, error) {
 			if buf.Len() != 0 {
 				result, err := strconv.Atoi(buf.String())
 				if err != nil {
-					return 0, char, pos, fmt.Errorf("BadCast")
+					return 0, char, pos, ErrBadCast
 				} else if result < 0 {
-					return 0, char, pos, fmt.Errorf("InvalidOffsetValue")
+					return 0, char, pos, ErrInvalidOffsetValue
 				}
 				return result, char, pos, nil
 			}
-			return 0, char, pos, fmt.Errorf("MissingOffsetValue")
+			return 0, char, pos, ErrMissingOffsetValue
 		}
 	}
-	return 0, char, pos, fmt.Errorf("UnbalancedBraces")
+	return 0, char, pos, ErrUnbalancedBraces
 }
@@ -39,6 +39,52 @@ func TestPlural(t *testing.T) {
 	)
 }
 
+func TestPluralPound(t *testing.T) {
+	doTest(t, Test{
+		"{N, plural, one {# item} other {# items}}",
+		[]Expectation{
+			{map[string]interface{}{"N": 12345}, "12,345 items"},
+			{map[string]interface{}{"N": 1234.5}, "1,234.5 items"},
+			{map[string]interface{}{"N": "1000.50"}, "1,000.50 items"},
+			{map[string]interface{}{"N": -1}, "-1 items"},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, plural, offset:1 other {# others}}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1235}, "1,234 others"},
+		},
+	})
+
+	doTestWithCulture(t, "fr", Test{
+		"{N, plural, one {# élément} other {# éléments}}",
+		[]Expectation{
+			{map[string]interface{}{"N": 12345}, "12\u202f345 éléments"},
+			{map[string]interface{}{"N": 1.5}, "1,5 élément"},
+		},
+	})
+
+	// a value too large to be expanded is written as is
+	doTest(t, Test{
+		"{N, plural, =1e999999999 {#!} other {# items}}",
+		[]Expectation{
+			{map[string]interface{}{"N": "1e999999999"}, "1e999999999!"},
+			{map[string]interface{}{"N": "1e401"}, "1e401 items"},
+		},
+	})
+
+	o, _ := New()
+	mf, _ := o.Parse("{N, plural, other {# items}}")
+
+	result, err := mf.FormatMap(map[string]interface{}{"N": 12345}, WithRawPound())
+	if err != nil {
+		t.Errorf("`WithRawPound` threw <%s>", err)
+	} else if result != "12345 items" {
+		t.Errorf("Expecting <%v> but got <%v>", "12345 items", result)
+	}
+}
+
 func BenchmarkPluralNonInteger(b *testing.B) {
 	doBenchmarkExecute(
 		b,
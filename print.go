@@ -0,0 +1,249 @@
+package messageformat
+
+import (
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// A PrintOption customizes the source printed from an AST.
+	PrintOption func(*printer)
+
+	printer struct {
+		strings.Builder
+		indent     string // indentation of the pretty output, none for the compact output
+		apostrophe ApostropheMode
+		depth      int
+		parent     string // type of the argument holding the printed message, empty for the root
+	}
+)
+
+// WithIndent prints each choice of the "select", "plural" and "selectordinal" arguments on its own line,
+// indented by the given string for each level of nesting.
+func WithIndent(indent string) PrintOption {
+	return func(x *printer) {
+		x.indent = indent
+	}
+}
+
+// WithApostropheMode prints the literal texts escaped for a Parser using the given mode.
+func WithApostropheMode(mode ApostropheMode) PrintOption {
+	return func(x *printer) {
+		x.apostrophe = mode
+	}
+}
+
+// pluralCategories lists the plural categories in their canonical order.
+var pluralCategories = []string{"zero", "one", "two", "few", "many", "other"}
+
+// String returns the canonical source of the message, escaped for the apostrophe mode it was parsed with.
+func (x *MessageFormat) String() string {
+	var buf strings.Builder
+	x.Print(&buf)
+	return buf.String()
+}
+
+// Print writes the canonical source of the message, escaped for the apostrophe mode it was parsed with.
+func (x *MessageFormat) Print(w io.Writer, options ...PrintOption) error {
+	return x.AST().Print(w, append([]PrintOption{WithApostropheMode(x.apostrophe)}, options...)...)
+}
+
+// String returns the canonical source of the message, escaped for the EscapeBackslash mode.
+func (x *Message) String() string {
+	var buf strings.Builder
+	x.Print(&buf)
+	return buf.String()
+}
+
+// Print writes the canonical source of the message, which parses into an equivalent AST.
+//
+// The choices of the "plural" and "selectordinal" arguments are sorted: the exact values first, then the
+// plural categories.
+func (x *Message) Print(w io.Writer, options ...PrintOption) error {
+	p := new(printer)
+	for _, option := range options {
+		option(p)
+	}
+
+	p.message(x)
+
+	_, err := io.WriteString(w, p.String())
+	return err
+}
+
+func (x *printer) message(m *Message) {
+	for _, n := range m.Nodes {
+		switch t := n.(type) {
+		case *Message:
+			x.message(t)
+
+		case *LiteralNode:
+			x.literal(t.Value)
+
+		case *ArgumentNode:
+			x.WriteString("{" + t.Name + "}")
+
+		case *SelectNode:
+			x.choices(t.Name, "select", "", t.Choices)
+
+		case *PluralNode:
+			offset := ""
+			if t.Offset != 0 {
+				offset = "offset:" + strconv.Itoa(t.Offset)
+			}
+			x.choices(t.Name, "plural", offset, sortPluralChoices(t.Choices))
+
+		case *OrdinalNode:
+			x.choices(t.Name, "selectordinal", "", sortPluralChoices(t.Choices))
+
+		case *ChoiceNode:
+			x.WriteString("{" + t.Name + ", choice, ")
+			for i, limit := range t.Limits {
+				if i != 0 {
+					x.WriteByte('|')
+				}
+				x.WriteString(formatChoiceLimit(limit.Limit))
+				if limit.Strict {
+					x.WriteByte('<')
+				} else {
+					x.WriteByte('#')
+				}
+				x.nested("choice", limit.Message)
+			}
+			x.WriteByte('}')
+
+		case *CustomNode:
+			x.WriteString("{" + t.Name + ", " + t.Type)
+			if t.Style != "" {
+				x.WriteString(", " + t.Style)
+			}
+			x.WriteByte('}')
+		}
+	}
+}
+
+// choices prints a "select", "plural" or "selectordinal" argument.
+func (x *printer) choices(name, ctype, offset string, choices []*Choice) {
+	x.WriteString("{" + name + ", " + ctype + ",")
+	if offset != "" {
+		x.WriteString(" " + offset)
+	}
+
+	x.depth++
+	for _, choice := range choices {
+		x.newline()
+		x.WriteString(choice.Key + "{")
+		x.nested(ctype, choice.Message)
+		x.WriteByte('}')
+	}
+	x.depth--
+
+	if x.indent != "" {
+		x.newline()
+	}
+	x.WriteByte('}')
+}
+
+// nested prints the message of a choice of an argument of the given type.
+func (x *printer) nested(ctype string, m *Message) {
+	parent := x.parent
+	x.parent = ctype
+	x.message(m)
+	x.parent = parent
+}
+
+// newline starts a new line of the pretty output, or separates two items of the compact output.
+func (x *printer) newline() {
+	if x.indent == "" {
+		x.WriteByte(' ')
+	} else {
+		x.WriteString("\n" + strings.Repeat(x.indent, x.depth))
+	}
+}
+
+// literal prints the parts of a literal text, escaping its syntax chars.
+func (x *printer) literal(parts []string) {
+	// the parts between two "#" are printed as a whole, so that the escaping can look at the previous chars
+	for i := 0; i < len(parts); i++ {
+		if parts[i] == "" {
+			x.WriteRune(PoundChar)
+			continue
+		}
+
+		text := parts[i]
+		for i+1 < len(parts) && parts[i+1] != "" {
+			i++
+			text += parts[i]
+		}
+
+		runes := []rune(text)
+		for j, c := range runes {
+			switch {
+			case x.apostrophe == ApostropheDoubleOptional && c == QuoteChar:
+				x.WriteString("''")
+
+			// a run of syntax chars is quoted as a whole, as "''" would be read as an apostrophe
+			case x.apostrophe == ApostropheDoubleOptional && isSyntaxChar(c, x.parent):
+				if j == 0 || !isSyntaxChar(runes[j-1], x.parent) {
+					x.WriteRune(QuoteChar)
+				}
+				x.WriteRune(c)
+				if j+1 == len(runes) || !isSyntaxChar(runes[j+1], x.parent) {
+					x.WriteRune(QuoteChar)
+				}
+
+			// a syntax char preceded by several backslashes is parsed as is
+			case x.apostrophe == EscapeBackslash && (c == OpenChar || c == CloseChar || c == PoundChar) &&
+				!(j > 1 && runes[j-1] == EscapeChar && runes[j-2] == EscapeChar):
+				x.WriteRune(EscapeChar)
+				x.WriteRune(c)
+
+			default:
+				x.WriteRune(c)
+			}
+		}
+	}
+}
+
+// formatChoiceLimit returns the source of a limit of a "choice" argument.
+func formatChoiceLimit(limit float64) string {
+	switch {
+	case math.IsInf(limit, 1):
+		return "∞"
+
+	case math.IsInf(limit, -1):
+		return "-∞"
+	}
+	return strconv.FormatFloat(limit, 'f', -1, 64)
+}
+
+// sortPluralChoices returns the choices sorted by exact value ("=n"), then by plural category.
+func sortPluralChoices(choices []*Choice) []*Choice {
+	result := append([]*Choice(nil), choices...)
+
+	rank := func(key string) (int, float64) {
+		if strings.HasPrefix(key, "=") {
+			value, _ := strconv.ParseFloat(key[1:], 64)
+			return 0, value
+		}
+		for i, category := range pluralCategories {
+			if key == category {
+				return 1, float64(i)
+			}
+		}
+		return 2, 0
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		gi, vi := rank(result[i].Key)
+		gj, vj := rank(result[j].Key)
+		if gi != gj {
+			return gi < gj
+		}
+		return vi < vj
+	})
+	return result
+}
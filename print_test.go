@@ -0,0 +1,91 @@
+package messageformat
+
+import (
+	"strings"
+	"testing"
+)
+
+func doTestPrint(t *testing.T, o *Parser, input, expected string, options ...PrintOption) {
+	mf, err := o.Parse(input)
+	if err != nil {
+		t.Errorf("`%s` threw <%s>", input, err)
+		return
+	}
+
+	var buf strings.Builder
+	if err := mf.Print(&buf, options...); err != nil {
+		t.Errorf("`%s` threw <%s>", input, err)
+	} else if buf.String() != expected {
+		t.Errorf("Expecting <%v> but got <%v>", expected, buf.String())
+	}
+
+	// the printed source parses into an equivalent tree
+	reparsed, err := o.Parse(buf.String())
+	if err != nil {
+		t.Errorf("`%s` threw <%s>", buf.String(), err)
+	} else if reparsed.String() != mf.String() {
+		t.Errorf("Expecting <%v> but got <%v>", mf.String(), reparsed.String())
+	} else if expected, err := mf.Format(); err == nil {
+		if result, _ := reparsed.Format(); result != expected {
+			t.Errorf("Expecting <%v> but got <%v>", expected, result)
+		}
+	}
+}
+
+func TestPrint(t *testing.T) {
+	o, _ := New()
+
+	doTestPrint(t, o, "Hello {NAME}", "Hello {NAME}")
+	doTestPrint(t, o, `\{\#\} {S, select, other{# is a \#}}`, `\{\#\} {S, select, other{# is a \#}}`)
+	doTestPrint(t, o, `he\\#ll\\\{o\\} \##!`, `he\\#ll\\\{o\\} \##!`)
+	doTestPrint(t, o, "{ G ,select,female {She}  male{He}other{They} }", "{G, select, female{She} male{He} other{They}}")
+	doTestPrint(
+		t, o,
+		"{N, plural, offset:1 other{# others} one {one other} =1{you} =0 {nobody} many{many}}",
+		"{N, plural, offset:1 =0{nobody} =1{you} one{one other} many{many} other{# others}}",
+	)
+	doTestPrint(t, o, "{R,selectordinal,other{#th}one{#st}}", "{R, selectordinal, one{#st} other{#th}}")
+	doTestPrint(t, o, "{N,choice,-∞#neg|0#none|0.5<{N,number}|∞#inf}", "{N, choice, -∞#neg|0#none|0.5<{N, number}|∞#inf}")
+	doTestPrint(t, o, "{N,number}{D, date,  ::yMMMd }", "{N, number}{D, date, ::yMMMd}")
+
+	doTestPrint(
+		t, o,
+		"{G, select, male{{N, plural, one{He has # file} other{He has # files}}} other{They have {N, plural, =0{none} other{#}}}}",
+		`{G, select,
+  male{{N, plural,
+    one{He has # file}
+    other{He has # files}
+  }}
+  other{They have {N, plural,
+    =0{none}
+    other{#}
+  }}
+}`,
+		WithIndent("  "),
+	)
+
+	o.SetApostropheMode(ApostropheDoubleOptional)
+	doTestPrint(t, o, `I don''t '{know}' '#' {N, plural, other{# '#'}}`, `I don''t '{'know'}' ''#'' {N, plural, other{# '#'}}`)
+	doTestPrint(t, o, `{N, choice, 0#a'|'b|1#c}`, `{N, choice, 0#a'|'b|1#c}`)
+	doTestPrint(t, o, `'{}' '{{' x'}'`, `'{}' '{{' x'}'`)
+	doTestPrint(t, o, `{N, plural, other{'}#' #}}`, `{N, plural, other{'}#' #}}`)
+	doTestPrint(t, o, `{N, choice, 0#'{|}'|1#'||'}`, `{N, choice, 0#'{|}'|1#'||'}`)
+
+	// an edited AST
+	mf, _ := o.Parse("Hi {name}!")
+	ast := mf.AST()
+	ast.Nodes[1] = &SelectNode{Name: "name", Choices: []*Choice{
+		{"bob", &Message{Nodes: []Node{&LiteralNode{Value: []string{"Bob{}"}}}}},
+		{"other", &Message{Nodes: []Node{&ArgumentNode{Name: "name"}}}},
+	}}
+
+	if result := ast.String(); result != `Hi {name, select, bob{Bob\{\}} other{{name}}}!` {
+		t.Errorf("Unexpected print result : `%s`", result)
+	}
+
+	var buf strings.Builder
+	ast.Print(&buf, WithApostropheMode(ApostropheDoubleOptional))
+	if result := buf.String(); result != `Hi {name, select, bob{Bob'{}'} other{{name}}}!` {
+		t.Errorf("Unexpected print result : `%s`", result)
+	}
+}
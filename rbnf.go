@@ -0,0 +1,486 @@
+package messageformat
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A ruleSet is a named set of rules of an ICU rule based number format (i.e. "%spellout-cardinal").
+//
+// see https://unicode-org.github.io/icu/userguide/format_parse/numbers/rbnf.html
+type ruleSet struct {
+	name     string
+	negative *numberRule   // "-x" rule, nil if the rule set does not handle negative numbers
+	fraction *numberRule   // "x.x" rule, nil if the rule set does not handle fractional numbers
+	rules    []*numberRule // sorted by base value
+}
+
+type numberRule struct {
+	base    int64
+	divisor int64
+	tokens  []ruleToken
+}
+
+// A ruleToken is either a literal text, a substitution or a plural selection.
+type ruleToken struct {
+	kind     rune              // 0 for a literal, '<', '>' or '=' for a substitution, '$' for a plural selection
+	text     string            // literal text, rule set name or decimal pattern of a substitution, plural type
+	optional bool              // true if omitted when the number is a multiple of the divisor of its rule
+	choices  map[string]string // text by plural category
+}
+
+var (
+	rbnfData = map[string]string{
+		"en": `
+%digits-ordinal:
+	-x: ->>;
+	0: =#,##0=$(ordinal,one{st}two{nd}few{rd}other{th})$;
+%spellout-cardinal:
+	-x: minus >>;
+	x.x: << point >>;
+	0: zero; 1: one; 2: two; 3: three; 4: four; 5: five; 6: six; 7: seven; 8: eight; 9: nine;
+	10: ten; 11: eleven; 12: twelve; 13: thirteen; 14: fourteen; 15: fifteen; 16: sixteen; 17: seventeen; 18: eighteen; 19: nineteen;
+	20: twenty[->>]; 30: thirty[->>]; 40: forty[->>]; 50: fifty[->>]; 60: sixty[->>]; 70: seventy[->>]; 80: eighty[->>]; 90: ninety[->>];
+	100: << hundred[ >>];
+	1000: << thousand[ >>];
+	1000000: << million[ >>];
+	1000000000: << billion[ >>];
+	1000000000000: << trillion[ >>];
+	1000000000000000: =#,##0=;
+`,
+		"fr": `
+%digits-ordinal:
+	-x: ->>;
+	0: =#,##0=$(ordinal,one{er}other{e})$;
+%spellout-cardinal:
+	-x: moins >>;
+	x.x: << virgule >>;
+	0: zéro; 1: un; 2: deux; 3: trois; 4: quatre; 5: cinq; 6: six; 7: sept; 8: huit; 9: neuf;
+	10: dix; 11: onze; 12: douze; 13: treize; 14: quatorze; 15: quinze; 16: seize; 17: dix->>;
+	20: vingt[->%%et-un>]; 30: trente[->%%et-un>]; 40: quarante[->%%et-un>]; 50: cinquante[->%%et-un>];
+	60/20: soixante[->%%et-un>];
+	80/20: quatre-vingt>%%vingts>;
+	100: cent[ >>];
+	200: << cent>%%cents>;
+	1000: mille[ >>];
+	2000: <%%leading< mille[ >>];
+	1000000: << million$(cardinal,one{}other{s})$[ >>];
+	1000000000: << milliard$(cardinal,one{}other{s})$[ >>];
+	1000000000000: =#,##0=;
+%%leading:
+	0: =%spellout-cardinal=;
+	80/20: quatre-vingt[->>];
+	100: cent[ >>];
+	200: << cent[ >>];
+%%et-un:
+	1: et-un; 2: =%spellout-cardinal=; 11: et-onze; 12: =%spellout-cardinal=;
+%%vingts:
+	0: s; 1: -=%spellout-cardinal=;
+%%cents:
+	0: s; 1: ' =%spellout-cardinal=;
+`,
+		"de": `
+%digits-ordinal:
+	-x: ->>;
+	0: =#,##0=.;
+%spellout-cardinal:
+	-x: minus >>;
+	x.x: << Komma >>;
+	0: null; 1: eins; 2: zwei; 3: drei; 4: vier; 5: fünf; 6: sechs; 7: sieben; 8: acht; 9: neun;
+	10: zehn; 11: elf; 12: zwölf; 13: >>zehn; 16: sechzehn; 17: siebzehn; 18: >>zehn;
+	20: [>%%ein>und]zwanzig; 30: [>%%ein>und]dreißig; 40: [>%%ein>und]vierzig; 50: [>%%ein>und]fünfzig;
+	60: [>%%ein>und]sechzig; 70: [>%%ein>und]siebzig; 80: [>%%ein>und]achtzig; 90: [>%%ein>und]neunzig;
+	100: <%%ein<hundert[>>];
+	1000: <%%ein<tausend[>>];
+	1000000: eine Million[ >>];
+	2000000: << Millionen[ >>];
+	1000000000: eine Milliarde[ >>];
+	2000000000: << Milliarden[ >>];
+	1000000000000: =#,##0=;
+%%ein:
+	1: ein; 2: =%spellout-cardinal=;
+`,
+		"es": `
+%digits-ordinal:
+	-x: ->>;
+	0: =#,##0=.º;
+%spellout-cardinal:
+	-x: menos >>;
+	x.x: << coma >>;
+	0: cero; 1: uno; 2: dos; 3: tres; 4: cuatro; 5: cinco; 6: seis; 7: siete; 8: ocho; 9: nueve;
+	10: diez; 11: once; 12: doce; 13: trece; 14: catorce; 15: quince; 16: dieciséis; 17: diecisiete; 18: dieciocho; 19: diecinueve;
+	20: veinte; 21: veintiuno; 22: veintidós; 23: veintitrés; 24: veinticuatro; 25: veinticinco; 26: veintiséis; 27: veintisiete; 28: veintiocho; 29: veintinueve;
+	30: treinta[ y >>]; 40: cuarenta[ y >>]; 50: cincuenta[ y >>]; 60: sesenta[ y >>]; 70: setenta[ y >>]; 80: ochenta[ y >>]; 90: noventa[ y >>];
+	100: cien; 101: ciento >>;
+	200: doscientos[ >>]; 300: trescientos[ >>]; 400: cuatrocientos[ >>]; 500: quinientos[ >>];
+	600: seiscientos[ >>]; 700: setecientos[ >>]; 800: ochocientos[ >>]; 900: novecientos[ >>];
+	1000: mil[ >>];
+	2000: <%%apocope< mil[ >>];
+	1000000: un millón[ >>];
+	2000000: <%%apocope< millones[ >>];
+	1000000000000: =#,##0=;
+%%apocope:
+	0: =%spellout-cardinal=; 1: un; 2: =%spellout-cardinal=; 21: veintiún; 22: =%spellout-cardinal=;
+	30: treinta[ y >>]; 40: cuarenta[ y >>]; 50: cincuenta[ y >>]; 60: sesenta[ y >>]; 70: setenta[ y >>]; 80: ochenta[ y >>]; 90: noventa[ y >>];
+	100: cien; 101: ciento >>;
+	200: doscientos[ >>]; 300: trescientos[ >>]; 400: cuatrocientos[ >>]; 500: quinientos[ >>];
+	600: seiscientos[ >>]; 700: setecientos[ >>]; 800: ochocientos[ >>]; 900: novecientos[ >>];
+	1000: mil[ >>]; 2000: <%%apocope< mil[ >>];
+`,
+	}
+
+	// compiled rule sets by culture
+	rbnfRules = make(map[string]map[string]*ruleSet)
+)
+
+func init() {
+	for name, data := range rbnfData {
+		sets, err := compileRuleSets(data)
+		if err != nil {
+			panic("messageformat: invalid rule sets for `" + name + "`: " + err.Error())
+		}
+		rbnfRules[name] = sets
+	}
+}
+
+// getRuleSets returns the rule sets of the given culture, falling back to "en", with the name of their culture.
+func getRuleSets(culture string) (map[string]*ruleSet, string) {
+	for _, name := range cultureNames(culture) {
+		if result, ok := rbnfRules[name]; ok {
+			return result, name
+		}
+	}
+	return rbnfRules["en"], "en"
+}
+
+// compileRuleSets parses rule sets written in the ICU syntax, i.e. "%name: 0: zero; 1: one; ...".
+//
+// Rule sets whose name starts with "%%" are private, they can only be used by the substitutions of the others.
+func compileRuleSets(input string) (map[string]*ruleSet, error) {
+	result := make(map[string]*ruleSet)
+
+	var current *ruleSet
+	for _, statement := range strings.Split(input, ";") {
+		statement = strings.TrimSpace(statement)
+		if statement == "" {
+			continue
+		}
+
+		if statement[0] == '%' {
+			i := strings.IndexByte(statement, ':')
+			if i == -1 {
+				return nil, fmt.Errorf("MissingRuleSetName: `%s`", statement)
+			}
+
+			current = &ruleSet{name: statement[:i]}
+			result[current.name] = current
+			statement = strings.TrimSpace(statement[i+1:])
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("MissingRuleSetName: `%s`", statement)
+		}
+
+		err := current.add(statement)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// checks that each substitution refers to an existing rule set
+	for _, set := range result {
+		sort.Slice(set.rules, func(i, j int) bool {
+			return set.rules[i].base < set.rules[j].base
+		})
+
+		for _, rule := range append([]*numberRule{set.negative, set.fraction}, set.rules...) {
+			if rule == nil {
+				continue
+			}
+
+			for _, token := range rule.tokens {
+				if token.kind != 0 && token.kind != '$' && strings.HasPrefix(token.text, "%") && result[token.text] == nil {
+					return nil, fmt.Errorf("%w: `%s`", ErrUnknownRuleSet, token.text)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// add parses a single rule, i.e. "20: twenty[->>]" or "60/20: soixante[->%%et-un>]".
+func (x *ruleSet) add(statement string) error {
+	i := strings.IndexByte(statement, ':')
+	if i == -1 {
+		return fmt.Errorf("MissingRuleDescriptor: `%s`", statement)
+	}
+
+	descriptor := strings.TrimSpace(statement[:i])
+	body := strings.TrimLeft(statement[i+1:], " \t\r\n")
+	if strings.HasPrefix(body, "'") {
+		body = body[1:]
+	}
+
+	tokens, err := compileRuleBody(body)
+	if err != nil {
+		return err
+	}
+
+	rule := &numberRule{divisor: 1, tokens: tokens}
+
+	switch descriptor {
+	case "-x":
+		x.negative = rule
+
+	case "x.x":
+		x.fraction = rule
+
+	default:
+		radix := int64(10)
+		if j := strings.IndexByte(descriptor, '/'); j != -1 {
+			radix, err = strconv.ParseInt(descriptor[j+1:], 10, 64)
+			if err != nil || radix < 2 {
+				return fmt.Errorf("InvalidRuleDescriptor: `%s`", descriptor)
+			}
+			descriptor = descriptor[:j]
+		}
+
+		rule.base, err = strconv.ParseInt(descriptor, 10, 64)
+		if err != nil || rule.base < 0 {
+			return fmt.Errorf("InvalidRuleDescriptor: `%s`", descriptor)
+		}
+
+		for rule.divisor <= rule.base/radix {
+			rule.divisor *= radix
+		}
+		x.rules = append(x.rules, rule)
+	}
+	return nil
+}
+
+// compileRuleBody splits the body of a rule into its tokens.
+func compileRuleBody(body string) ([]ruleToken, error) {
+	var result []ruleToken
+	var text strings.Builder
+
+	optional := false
+	flush := func() {
+		if text.Len() != 0 {
+			result = append(result, ruleToken{text: text.String(), optional: optional})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(body); i++ {
+		c := body[i]
+
+		switch {
+		case c == '[' || c == ']':
+			if optional == (c == '[') {
+				return nil, fmt.Errorf("UnbalancedBrackets: `%s`", body)
+			}
+			flush()
+			optional = c == '['
+
+		case c == '<' || c == '>' || c == '=':
+			j := strings.IndexByte(body[i+1:], c)
+			if j == -1 {
+				return nil, fmt.Errorf("UnclosedSubstitution: `%s`", body)
+			}
+			flush()
+			result = append(result, ruleToken{kind: rune(c), text: body[i+1 : i+1+j], optional: optional})
+			i += j + 1
+
+		case c == '$' && strings.HasPrefix(body[i:], "$("):
+			j := strings.Index(body[i:], ")$")
+			if j == -1 {
+				return nil, fmt.Errorf("UnclosedPlural: `%s`", body)
+			}
+
+			token, err := compilePluralToken(body[i+2 : i+j])
+			if err != nil {
+				return nil, err
+			}
+			flush()
+			token.optional = optional
+			result = append(result, token)
+			i += j + 1
+
+		default:
+			text.WriteByte(c)
+		}
+	}
+
+	if optional {
+		return nil, fmt.Errorf("UnbalancedBrackets: `%s`", body)
+	}
+	flush()
+	return result, nil
+}
+
+// compilePluralToken parses the content of a plural selection, i.e. "cardinal,one{}other{s}".
+func compilePluralToken(input string) (ruleToken, error) {
+	result := ruleToken{kind: '$', choices: make(map[string]string)}
+
+	i := strings.IndexByte(input, ',')
+	if i == -1 {
+		return result, fmt.Errorf("InvalidPlural: `%s`", input)
+	}
+
+	result.text = input[:i]
+	if result.text != "cardinal" && result.text != "ordinal" {
+		return result, fmt.Errorf("InvalidPlural: `%s`", input)
+	}
+
+	for rest := input[i+1:]; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		end := strings.IndexByte(rest, '}')
+		if open <= 0 || end < open {
+			return result, fmt.Errorf("InvalidPlural: `%s`", input)
+		}
+
+		result.choices[rest[:open]] = rest[open+1 : end]
+		rest = rest[end+1:]
+	}
+
+	if _, ok := result.choices["other"]; !ok {
+		return result, fmt.Errorf("InvalidPlural: `%s`", input)
+	}
+	return result, nil
+}
+
+// format writes the value using the rules of the set, the other sets being used by the substitutions.
+func (x *ruleSet) format(ptr_output Writer, value decimal, sets map[string]*ruleSet, ptr_mf *MessageFormat) error {
+	if value.neg && !value.isZero() && x.negative != nil {
+		value.neg = false
+		return x.apply(ptr_output, x.negative, value, sets, ptr_mf)
+	}
+
+	value.trim(0)
+	if value.fraction != "" && x.fraction != nil {
+		return x.apply(ptr_output, x.fraction, value, sets, ptr_mf)
+	}
+
+	value.round(0, roundHalfEven)
+
+	n, err := strconv.ParseInt("0"+value.integer, 10, 64)
+	if err != nil || (value.neg && !value.isZero()) {
+		ptr_output.WriteString(formatDigits(value, ptr_mf.culture))
+		return nil
+	}
+	return x.formatInteger(ptr_output, n, sets, ptr_mf)
+}
+
+// apply writes a value using the negative or the fraction rule of the set.
+func (x *ruleSet) apply(ptr_output Writer, rule *numberRule, value decimal, sets map[string]*ruleSet, ptr_mf *MessageFormat) error {
+	integer := decimal{integer: value.integer}
+
+	for _, token := range rule.tokens {
+		var err error
+
+		switch token.kind {
+		case 0:
+			ptr_output.WriteString(token.text)
+
+		case '<':
+			err = x.substitute(ptr_output, token.text, integer, sets, ptr_mf)
+
+		case '>':
+			if rule != x.fraction {
+				err = x.substitute(ptr_output, token.text, value, sets, ptr_mf)
+				break
+			}
+
+			// the fractional digits are written one by one
+			for i := 0; i < len(value.fraction) && err == nil; i++ {
+				if i > 0 {
+					ptr_output.WriteByte(' ')
+				}
+				err = x.substitute(ptr_output, token.text, decimal{integer: strings.TrimLeft(value.fraction[i:i+1], "0")}, sets, ptr_mf)
+			}
+
+		case '=':
+			err = x.substitute(ptr_output, token.text, value, sets, ptr_mf)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatInteger writes a non negative integer using the rule with the highest base value lower or equal to it.
+func (x *ruleSet) formatInteger(ptr_output Writer, n int64, sets map[string]*ruleSet, ptr_mf *MessageFormat) error {
+	i := sort.Search(len(x.rules), func(i int) bool {
+		return x.rules[i].base > n
+	})
+	if i == 0 {
+		return fmt.Errorf("Spellout: No rule for %d in `%s`", n, x.name)
+	}
+
+	rule := x.rules[i-1]
+	quotient, remainder := n/rule.divisor, n%rule.divisor
+
+	for _, token := range rule.tokens {
+		if token.optional && remainder == 0 {
+			continue
+		}
+
+		var err error
+
+		switch token.kind {
+		case 0:
+			ptr_output.WriteString(token.text)
+
+		case '<':
+			err = x.substitute(ptr_output, token.text, integerDecimal(quotient), sets, ptr_mf)
+
+		case '>':
+			err = x.substitute(ptr_output, token.text, integerDecimal(remainder), sets, ptr_mf)
+
+		case '=':
+			err = x.substitute(ptr_output, token.text, integerDecimal(n), sets, ptr_mf)
+
+		case '$':
+			var key string
+			key, err = ptr_mf.getNamedKey(quotient, token.text == "ordinal")
+			if err == nil {
+				text, ok := token.choices[key]
+				if !ok {
+					text = token.choices["other"]
+				}
+				ptr_output.WriteString(text)
+			}
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// substitute writes a value using the named rule set, the current one when the name is empty,
+// or as a decimal number of the culture when the name is a decimal pattern (i.e. "#,##0").
+func (x *ruleSet) substitute(ptr_output Writer, name string, value decimal, sets map[string]*ruleSet, ptr_mf *MessageFormat) error {
+	switch {
+	case name == "":
+		return x.format(ptr_output, value, sets, ptr_mf)
+
+	case strings.HasPrefix(name, "%"):
+		return sets[name].format(ptr_output, value, sets, ptr_mf)
+	}
+
+	ptr_output.WriteString(formatDigits(value, ptr_mf.culture))
+	return nil
+}
+
+func integerDecimal(n int64) decimal {
+	if n == 0 {
+		return decimal{}
+	}
+	return decimal{integer: strconv.FormatInt(n, 10)}
+}
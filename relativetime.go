@@ -0,0 +1,414 @@
+package messageformat
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+type relativeTimeExpr struct {
+	key     string
+	width   string // "long", "short" or "narrow"
+	numeric bool   // true to always use a number (i.e. "1 day ago" instead of "yesterday")
+	unit    string // unit to use, empty to pick the best one
+}
+
+// A relativeUnit holds the patterns used to format a relative time in a unit.
+type relativeUnit struct {
+	future   map[string]string // patterns by plural category
+	past     map[string]string // patterns by plural category
+	relative map[int]string    // named offsets (i.e. -1: "yesterday"), nil to use the ones of a wider width
+}
+
+// relativeUnits lists the units of a relative time, from the smallest to the largest, with the limit
+// (in seconds) under which they are picked.
+var relativeUnits = []struct {
+	name  string
+	size  float64
+	limit float64
+}{
+	{"second", 1, 45},
+	{"minute", 60, 45 * 60},
+	{"hour", 3600, 22 * 3600},
+	{"day", 86400, 7 * 86400},
+	{"week", 7 * 86400, 26 * 86400},
+	{"month", 30.436875 * 86400, 320 * 86400},
+	{"year", 365.2425 * 86400, math.Inf(1)},
+}
+
+// relativeData holds the relative time patterns by culture, then width and unit.
+//
+// A missing width or named offsets fall back to the ones of the "short" then of the "long" width.
+var relativeData = map[string]map[string]map[string]*relativeUnit{
+	"en": {
+		"long": {
+			"second": {
+				map[string]string{"one": "in {0} second", "other": "in {0} seconds"},
+				map[string]string{"one": "{0} second ago", "other": "{0} seconds ago"},
+				map[int]string{0: "now"},
+			},
+			"minute": {
+				map[string]string{"one": "in {0} minute", "other": "in {0} minutes"},
+				map[string]string{"one": "{0} minute ago", "other": "{0} minutes ago"},
+				map[int]string{0: "this minute"},
+			},
+			"hour": {
+				map[string]string{"one": "in {0} hour", "other": "in {0} hours"},
+				map[string]string{"one": "{0} hour ago", "other": "{0} hours ago"},
+				map[int]string{0: "this hour"},
+			},
+			"day": {
+				map[string]string{"one": "in {0} day", "other": "in {0} days"},
+				map[string]string{"one": "{0} day ago", "other": "{0} days ago"},
+				map[int]string{-1: "yesterday", 0: "today", 1: "tomorrow"},
+			},
+			"week": {
+				map[string]string{"one": "in {0} week", "other": "in {0} weeks"},
+				map[string]string{"one": "{0} week ago", "other": "{0} weeks ago"},
+				map[int]string{-1: "last week", 0: "this week", 1: "next week"},
+			},
+			"month": {
+				map[string]string{"one": "in {0} month", "other": "in {0} months"},
+				map[string]string{"one": "{0} month ago", "other": "{0} months ago"},
+				map[int]string{-1: "last month", 0: "this month", 1: "next month"},
+			},
+			"year": {
+				map[string]string{"one": "in {0} year", "other": "in {0} years"},
+				map[string]string{"one": "{0} year ago", "other": "{0} years ago"},
+				map[int]string{-1: "last year", 0: "this year", 1: "next year"},
+			},
+		},
+		"short": {
+			"second": {map[string]string{"other": "in {0} sec."}, map[string]string{"other": "{0} sec. ago"}, nil},
+			"minute": {map[string]string{"other": "in {0} min."}, map[string]string{"other": "{0} min. ago"}, nil},
+			"hour":   {map[string]string{"other": "in {0} hr."}, map[string]string{"other": "{0} hr. ago"}, nil},
+			"day": {
+				map[string]string{"one": "in {0} day", "other": "in {0} days"},
+				map[string]string{"one": "{0} day ago", "other": "{0} days ago"},
+				nil,
+			},
+			"week": {
+				map[string]string{"other": "in {0} wk."},
+				map[string]string{"other": "{0} wk. ago"},
+				map[int]string{-1: "last wk.", 0: "this wk.", 1: "next wk."},
+			},
+			"month": {
+				map[string]string{"other": "in {0} mo."},
+				map[string]string{"other": "{0} mo. ago"},
+				map[int]string{-1: "last mo.", 0: "this mo.", 1: "next mo."},
+			},
+			"year": {
+				map[string]string{"other": "in {0} yr."},
+				map[string]string{"other": "{0} yr. ago"},
+				map[int]string{-1: "last yr.", 0: "this yr.", 1: "next yr."},
+			},
+		},
+		"narrow": {
+			"second": {map[string]string{"other": "in {0}s"}, map[string]string{"other": "{0}s ago"}, nil},
+			"minute": {map[string]string{"other": "in {0}m"}, map[string]string{"other": "{0}m ago"}, nil},
+			"hour":   {map[string]string{"other": "in {0}h"}, map[string]string{"other": "{0}h ago"}, nil},
+			"day":    {map[string]string{"other": "in {0}d"}, map[string]string{"other": "{0}d ago"}, nil},
+			"week":   {map[string]string{"other": "in {0}w"}, map[string]string{"other": "{0}w ago"}, nil},
+			"month":  {map[string]string{"other": "in {0}mo"}, map[string]string{"other": "{0}mo ago"}, nil},
+			"year":   {map[string]string{"other": "in {0}y"}, map[string]string{"other": "{0}y ago"}, nil},
+		},
+	},
+	"fr": {
+		"long": {
+			"second": {
+				map[string]string{"one": "dans {0} seconde", "other": "dans {0} secondes"},
+				map[string]string{"one": "il y a {0} seconde", "other": "il y a {0} secondes"},
+				map[int]string{0: "maintenant"},
+			},
+			"minute": {
+				map[string]string{"one": "dans {0} minute", "other": "dans {0} minutes"},
+				map[string]string{"one": "il y a {0} minute", "other": "il y a {0} minutes"},
+				map[int]string{0: "cette minute-ci"},
+			},
+			"hour": {
+				map[string]string{"one": "dans {0} heure", "other": "dans {0} heures"},
+				map[string]string{"one": "il y a {0} heure", "other": "il y a {0} heures"},
+				map[int]string{0: "cette heure-ci"},
+			},
+			"day": {
+				map[string]string{"one": "dans {0} jour", "other": "dans {0} jours"},
+				map[string]string{"one": "il y a {0} jour", "other": "il y a {0} jours"},
+				map[int]string{-2: "avant-hier", -1: "hier", 0: "aujourd’hui", 1: "demain", 2: "après-demain"},
+			},
+			"week": {
+				map[string]string{"one": "dans {0} semaine", "other": "dans {0} semaines"},
+				map[string]string{"one": "il y a {0} semaine", "other": "il y a {0} semaines"},
+				map[int]string{-1: "la semaine dernière", 0: "cette semaine", 1: "la semaine prochaine"},
+			},
+			"month": {
+				map[string]string{"other": "dans {0} mois"},
+				map[string]string{"other": "il y a {0} mois"},
+				map[int]string{-1: "le mois dernier", 0: "ce mois-ci", 1: "le mois prochain"},
+			},
+			"year": {
+				map[string]string{"one": "dans {0} an", "other": "dans {0} ans"},
+				map[string]string{"one": "il y a {0} an", "other": "il y a {0} ans"},
+				map[int]string{-1: "l’année dernière", 0: "cette année", 1: "l’année prochaine"},
+			},
+		},
+		"short": {
+			"second": {map[string]string{"other": "dans {0}\u00a0s"}, map[string]string{"other": "il y a {0}\u00a0s"}, nil},
+			"minute": {map[string]string{"other": "dans {0}\u00a0min"}, map[string]string{"other": "il y a {0}\u00a0min"}, nil},
+			"hour":   {map[string]string{"other": "dans {0}\u00a0h"}, map[string]string{"other": "il y a {0}\u00a0h"}, nil},
+			"day":    {map[string]string{"other": "dans {0}\u00a0j"}, map[string]string{"other": "il y a {0}\u00a0j"}, nil},
+			"week":   {map[string]string{"other": "dans {0}\u00a0sem."}, map[string]string{"other": "il y a {0}\u00a0sem."}, nil},
+			"month":  {map[string]string{"other": "dans {0}\u00a0m."}, map[string]string{"other": "il y a {0}\u00a0m."}, nil},
+			"year":   {map[string]string{"other": "dans {0}\u00a0a"}, map[string]string{"other": "il y a {0}\u00a0a"}, nil},
+		},
+	},
+	"de": {
+		"long": {
+			"second": {
+				map[string]string{"one": "in {0} Sekunde", "other": "in {0} Sekunden"},
+				map[string]string{"one": "vor {0} Sekunde", "other": "vor {0} Sekunden"},
+				map[int]string{0: "jetzt"},
+			},
+			"minute": {
+				map[string]string{"one": "in {0} Minute", "other": "in {0} Minuten"},
+				map[string]string{"one": "vor {0} Minute", "other": "vor {0} Minuten"},
+				map[int]string{0: "in dieser Minute"},
+			},
+			"hour": {
+				map[string]string{"one": "in {0} Stunde", "other": "in {0} Stunden"},
+				map[string]string{"one": "vor {0} Stunde", "other": "vor {0} Stunden"},
+				map[int]string{0: "in dieser Stunde"},
+			},
+			"day": {
+				map[string]string{"one": "in {0} Tag", "other": "in {0} Tagen"},
+				map[string]string{"one": "vor {0} Tag", "other": "vor {0} Tagen"},
+				map[int]string{-2: "vorgestern", -1: "gestern", 0: "heute", 1: "morgen", 2: "übermorgen"},
+			},
+			"week": {
+				map[string]string{"one": "in {0} Woche", "other": "in {0} Wochen"},
+				map[string]string{"one": "vor {0} Woche", "other": "vor {0} Wochen"},
+				map[int]string{-1: "letzte Woche", 0: "diese Woche", 1: "nächste Woche"},
+			},
+			"month": {
+				map[string]string{"one": "in {0} Monat", "other": "in {0} Monaten"},
+				map[string]string{"one": "vor {0} Monat", "other": "vor {0} Monaten"},
+				map[int]string{-1: "letzten Monat", 0: "diesen Monat", 1: "nächsten Monat"},
+			},
+			"year": {
+				map[string]string{"one": "in {0} Jahr", "other": "in {0} Jahren"},
+				map[string]string{"one": "vor {0} Jahr", "other": "vor {0} Jahren"},
+				map[int]string{-1: "letztes Jahr", 0: "dieses Jahr", 1: "nächstes Jahr"},
+			},
+		},
+		"short": {
+			"second": {map[string]string{"other": "in {0} Sek."}, map[string]string{"other": "vor {0} Sek."}, nil},
+			"minute": {map[string]string{"other": "in {0} Min."}, map[string]string{"other": "vor {0} Min."}, nil},
+			"hour":   {map[string]string{"other": "in {0} Std."}, map[string]string{"other": "vor {0} Std."}, nil},
+			"day": {
+				map[string]string{"one": "in {0} Tag", "other": "in {0} Tagen"},
+				map[string]string{"one": "vor {0} Tag", "other": "vor {0} Tagen"},
+				nil,
+			},
+			"week":  {map[string]string{"other": "in {0} Wo."}, map[string]string{"other": "vor {0} Wo."}, nil},
+			"month": {map[string]string{"other": "in {0} Mon."}, map[string]string{"other": "vor {0} Mon."}, nil},
+			"year":  {map[string]string{"other": "in {0} J."}, map[string]string{"other": "vor {0} J."}, nil},
+		},
+	},
+	"es": {
+		"long": {
+			"second": {
+				map[string]string{"one": "dentro de {0} segundo", "other": "dentro de {0} segundos"},
+				map[string]string{"one": "hace {0} segundo", "other": "hace {0} segundos"},
+				map[int]string{0: "ahora"},
+			},
+			"minute": {
+				map[string]string{"one": "dentro de {0} minuto", "other": "dentro de {0} minutos"},
+				map[string]string{"one": "hace {0} minuto", "other": "hace {0} minutos"},
+				map[int]string{0: "este minuto"},
+			},
+			"hour": {
+				map[string]string{"one": "dentro de {0} hora", "other": "dentro de {0} horas"},
+				map[string]string{"one": "hace {0} hora", "other": "hace {0} horas"},
+				map[int]string{0: "esta hora"},
+			},
+			"day": {
+				map[string]string{"one": "dentro de {0} día", "other": "dentro de {0} días"},
+				map[string]string{"one": "hace {0} día", "other": "hace {0} días"},
+				map[int]string{-2: "anteayer", -1: "ayer", 0: "hoy", 1: "mañana", 2: "pasado mañana"},
+			},
+			"week": {
+				map[string]string{"one": "dentro de {0} semana", "other": "dentro de {0} semanas"},
+				map[string]string{"one": "hace {0} semana", "other": "hace {0} semanas"},
+				map[int]string{-1: "la semana pasada", 0: "esta semana", 1: "la próxima semana"},
+			},
+			"month": {
+				map[string]string{"one": "dentro de {0} mes", "other": "dentro de {0} meses"},
+				map[string]string{"one": "hace {0} mes", "other": "hace {0} meses"},
+				map[int]string{-1: "el mes pasado", 0: "este mes", 1: "el próximo mes"},
+			},
+			"year": {
+				map[string]string{"one": "dentro de {0} año", "other": "dentro de {0} años"},
+				map[string]string{"one": "hace {0} año", "other": "hace {0} años"},
+				map[int]string{-1: "el año pasado", 0: "este año", 1: "el próximo año"},
+			},
+		},
+		"short": {
+			"second": {map[string]string{"other": "dentro de {0} s"}, map[string]string{"other": "hace {0} s"}, nil},
+			"minute": {map[string]string{"other": "dentro de {0} min"}, map[string]string{"other": "hace {0} min"}, nil},
+			"hour":   {map[string]string{"other": "dentro de {0} h"}, map[string]string{"other": "hace {0} h"}, nil},
+			"day":    {map[string]string{"other": "dentro de {0} d"}, map[string]string{"other": "hace {0} d"}, nil},
+			"week":   {map[string]string{"other": "dentro de {0} sem."}, map[string]string{"other": "hace {0} sem."}, nil},
+			"month":  {map[string]string{"other": "dentro de {0} m."}, map[string]string{"other": "hace {0} m."}, nil},
+			"year":   {map[string]string{"other": "dentro de {0} a."}, map[string]string{"other": "hace {0} a."}, nil},
+		},
+	},
+}
+
+// getRelativeUnit returns the patterns of a unit in the given culture and width, falling back to "en", with the
+// name of their culture.
+func getRelativeUnit(culture, width, unit string) (*relativeUnit, string) {
+	data, dataCulture := relativeData["en"], "en"
+	for _, name := range cultureNames(culture) {
+		if result, ok := relativeData[name]; ok {
+			data, dataCulture = result, name
+			break
+		}
+	}
+
+	widths := []string{"long"}
+	switch width {
+	case "narrow":
+		widths = []string{"narrow", "short", "long"}
+
+	case "short":
+		widths = []string{"short", "long"}
+	}
+
+	result := new(relativeUnit)
+	for _, w := range widths {
+		if r, ok := data[w][unit]; ok {
+			if result.future == nil {
+				result.future, result.past = r.future, r.past
+			}
+
+			if r.relative != nil {
+				result.relative = r.relative
+				break
+			}
+		}
+	}
+	return result, dataCulture
+}
+
+func parseRelativeTime(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	result := new(relativeTimeExpr)
+	result.key = varname
+	result.width = "long"
+
+	if char == CloseChar {
+		return result, start, nil
+	}
+
+	style, pos, err := readStyle(start+1, end, ptr_input)
+	if err != nil {
+		return nil, pos, err
+	} else if style == "" {
+		return nil, pos, ErrMissingStyle
+	}
+
+	for _, token := range strings.Fields(style) {
+		switch token {
+		default:
+			if relativeUnitIndex(token) == -1 {
+				return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+			}
+			result.unit = token
+
+		case "long", "short", "narrow":
+			result.width = token
+
+		case "numeric", "auto":
+			result.numeric = token == "numeric"
+		}
+	}
+	return result, pos, nil
+}
+
+func relativeUnitIndex(name string) int {
+	for i, unit := range relativeUnits {
+		if unit.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// formatRelativeTime is the format function associated with the "relativetime" type.
+//
+// The value is either a time.Time (or a Unix timestamp), compared to the reference time given with WithNow, or the
+// time.Duration from the reference time.
+//
+// It will returns an error if :
+// - the associated value is not a time.Time, a Unix timestamp (in seconds) or a time.Duration
+// - the pluralFunc is not defined (MessageFormat.getNamedKey)
+//
+// It will writes nothing if its key can't be found in the given map
+func formatRelativeTime(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+	o := expr.(*relativeTimeExpr)
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	} else if !ok || v == nil {
+		return nil
+	}
+
+	delta, ok := v.(time.Duration)
+	if !ok {
+		value, err := toTime(v)
+		if err != nil {
+			return err
+		}
+
+		now := ptr_mf.now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		delta = value.Sub(now)
+	}
+
+	seconds := math.Abs(delta.Seconds())
+
+	i := relativeUnitIndex(o.unit)
+	if i == -1 {
+		for i = 0; seconds >= relativeUnits[i].limit; i++ {
+		}
+	}
+
+	amount := math.Round(seconds / relativeUnits[i].size)
+	patterns, culture := getRelativeUnit(ptr_mf.culture, o.width, relativeUnits[i].name)
+
+	offset := int(amount)
+	if delta < 0 {
+		offset = -offset
+	}
+
+	if text, ok := patterns.relative[offset]; ok && !o.numeric {
+		ptr_output.WriteString(text)
+		return nil
+	}
+
+	forms := patterns.future
+	if offset < 0 {
+		forms = patterns.past
+	}
+
+	number, _ := parseDecimal(fmt.Sprintf("%.0f", amount))
+
+	result, err := formatCount(forms, number, ptr_mf.pluralFor(culture))
+	if err != nil {
+		return err
+	}
+	ptr_output.WriteString(result)
+	return nil
+}
@@ -0,0 +1,76 @@
+package messageformat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2015, time.March, 7, 16, 5, 9, 0, time.UTC)
+
+	doTestWithOptions(t, "en", Test{
+		"{T, relativetime}|{T, relativetime, numeric}|{T, relativetime, short}|{T, relativetime, narrow}",
+		[]Expectation{
+			{map[string]interface{}{"T": now}, "now|in 0 seconds|now|now"},
+			{map[string]interface{}{"T": now.Add(-3 * time.Minute)}, "3 minutes ago|3 minutes ago|3 min. ago|3m ago"},
+			{map[string]interface{}{"T": now.Add(time.Hour)}, "in 1 hour|in 1 hour|in 1 hr.|in 1h"},
+			{map[string]interface{}{"T": now.Add(-30 * time.Hour)}, "yesterday|1 day ago|yesterday|yesterday"},
+			{map[string]interface{}{"T": now.Add(48 * time.Hour)}, "in 2 days|in 2 days|in 2 days|in 2d"},
+			{map[string]interface{}{"T": now.AddDate(0, 0, -14)}, "2 weeks ago|2 weeks ago|2 wk. ago|2w ago"},
+			{map[string]interface{}{"T": now.AddDate(0, 2, 0)}, "in 2 months|in 2 months|in 2 mo.|in 2mo"},
+			{map[string]interface{}{"T": now.AddDate(-1, 0, 0)}, "last year|1 year ago|last yr.|last yr."},
+			{map[string]interface{}{"T": now.Unix() + 20}, "in 20 seconds|in 20 seconds|in 20 sec.|in 20s"},
+			{map[string]interface{}{"T": 90 * time.Second}, "in 2 minutes|in 2 minutes|in 2 min.|in 2m"},
+			{nil, "|||"},
+		},
+	}, WithNow(now))
+
+	doTestWithOptions(t, "en", Test{
+		"{T, relativetime, hour}|{T, relativetime, day numeric}",
+		[]Expectation{
+			{map[string]interface{}{"T": now.Add(-48 * time.Hour)}, "48 hours ago|2 days ago"},
+		},
+	}, WithNow(now))
+
+	doTestException(
+		t,
+		"{T, relativetime}",
+		map[string]interface{}{"T": "yesterday"},
+		"Date: Unsupported type: string",
+	)
+}
+
+func TestRelativeTimeCulture(t *testing.T) {
+	now := time.Date(2015, time.March, 7, 16, 5, 9, 0, time.UTC)
+
+	doTestWithOptions(t, "fr", Test{
+		"{T, relativetime}|{T, relativetime, short}",
+		[]Expectation{
+			{map[string]interface{}{"T": now.Add(-24 * time.Hour)}, "hier|hier"},
+			{map[string]interface{}{"T": now.Add(-5 * time.Minute)}, "il y a 5 minutes|il y a 5\u00a0min"},
+			{map[string]interface{}{"T": now.AddDate(0, 0, 2)}, "après-demain|après-demain"},
+			{map[string]interface{}{"T": now.AddDate(3, 0, 0)}, "dans 3 ans|dans 3\u00a0a"},
+		},
+	}, WithNow(now))
+
+	doTestWithOptions(t, "de", Test{
+		"{T, relativetime}",
+		[]Expectation{
+			{map[string]interface{}{"T": now.Add(-3 * 24 * time.Hour)}, "vor 3 Tagen"},
+		},
+	}, WithNow(now))
+
+	// the "en" patterns are chosen with the "en" plural rules for a culture without patterns
+	doTestWithOptions(t, "ja", Test{
+		"{T, relativetime}",
+		[]Expectation{
+			{map[string]interface{}{"T": now.Add(time.Hour)}, "in 1 hour"},
+			{map[string]interface{}{"T": now.Add(-2 * time.Hour)}, "2 hours ago"},
+		},
+	}, WithNow(now))
+}
+
+func TestRelativeTimeParseException(t *testing.T) {
+	doTestParseException(t, "{T, relativetime, }", "ParseError: `MissingStyle` at 18")
+	doTestParseException(t, "{T, relativetime, fortnight}", "ParseError: `InvalidStyle: `fortnight`` at 27")
+}
@@ -1,22 +1,27 @@
 package messageformat
 
-import (
-	"bytes"
-	"errors"
-)
-
 type selectExpr struct {
 	key     string
 	choices map[string]*node
 }
 
 func parseSelect(varname string, ptr_compiler *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	return readSelect(varname, "select", ptr_compiler, char, start, end, ptr_input)
+}
+
+// parseOrdinal is the parse function associated with the "selectordinal" type.
+func parseOrdinal(varname string, ptr_compiler *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	return readSelect(varname, "selectordinal", ptr_compiler, char, start, end, ptr_input)
+}
+
+// readSelect parses the choices of a "select" or "selectordinal" argument.
+func readSelect(varname, ctype string, ptr_compiler *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
 	result := new(selectExpr)
 	result.key = varname
 	result.choices = make(map[string]*node)
 
 	if char != PartChar {
-		return nil, start, errors.New("MalformedOption")
+		return nil, start, ErrMalformedOption
 	}
 
 	hasOtherChoice := false
@@ -29,14 +34,14 @@ func parseSelect(varname string, ptr_compiler *Parser, char rune, start, end int
 		if err != nil {
 			return nil, i, err
 		} else if char == ':' {
-			return nil, i, errors.New("UnexpectedExtension")
+			return nil, i, ErrUnexpectedExtension
 		}
 
 		if key == "other" {
 			hasOtherChoice = true
 		}
 
-		choice, char, i, err := readChoice(ptr_compiler, char, i, end, ptr_input)
+		choice, char, i, err := readChoice(ptr_compiler, ctype, char, i, end, ptr_input)
 		if err != nil {
 			return nil, i, err
 		}
@@ -50,7 +55,7 @@ func parseSelect(varname string, ptr_compiler *Parser, char rune, start, end int
 	}
 
 	if !hasOtherChoice {
-		return nil, pos, errors.New("MissingMandatoryChoice")
+		return nil, pos, ErrMissingMandatoryChoice
 	}
 	return result, pos, nil
 }
@@ -63,7 +68,7 @@ func parseSelect(varname string, ptr_compiler *Parser, char rune, start, end int
 //
 // It will returns an error if :
 // - the associated value can't be convert to string (i.e. struct {}, ...)
-func formatSelect(expr Expression, ptr_output *bytes.Buffer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+func formatSelect(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
 	o := expr.(*selectExpr)
 
 	value, err := toString(*data, o.key)
@@ -71,11 +76,13 @@ func formatSelect(expr Expression, ptr_output *bytes.Buffer, data *map[string]in
 		return err
 	}
 
-	choice, ok := o.choices[value]
+	key := value
+	choice, ok := o.choices[key]
 	if !ok {
-		choice = o.choices["other"]
+		key = "other"
+		choice = o.choices[key]
 	}
-	return choice.format(ptr_output, data, ptr_mf, value)
+	return withChoice(choice.format(ptr_output, data, ptr_mf, value), o.key, key)
 }
 
 func readKey(start, end int, ptr_input *[]rune) (string, rune, int, error) {
@@ -97,7 +104,7 @@ func readKey(start, end int, ptr_input *[]rune) (string, rune, int, error) {
 			if fc_pos != lc_pos {
 				return string(input[fc_pos:lc_pos]), char, pos, nil
 			}
-			return "", char, pos, errors.New("MissingChoiceName")
+			return "", char, pos, ErrMissingChoiceName
 		}
 
 		pos++
@@ -106,15 +113,16 @@ func readKey(start, end int, ptr_input *[]rune) (string, rune, int, error) {
 			char = input[pos]
 		}
 	}
-	return "", char, pos, errors.New("UnbalancedBraces")
+	return "", char, pos, ErrUnbalancedBraces
 }
 
-func readChoice(ptr_compiler *Parser, char rune, pos, end int, ptr_input *[]rune) (*node, rune, int, error) {
+// readChoice parses the message of a choice of a "select", "plural" or "selectordinal" argument.
+func readChoice(ptr_compiler *Parser, ctype string, char rune, pos, end int, ptr_input *[]rune) (*node, rune, int, error) {
 	if char != OpenChar {
-		return nil, char, pos, errors.New("MissingChoiceContent")
+		return nil, char, pos, ErrMissingChoiceContent
 	}
 
-	choice := new(node)
+	choice := &node{parent: ctype}
 
 	pos, _, err := ptr_compiler.parse(pos+1, end, ptr_input, choice)
 	if err != nil {
@@ -0,0 +1,74 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+)
+
+type spelloutExpr struct {
+	key     string
+	ruleSet string // name of the public rule set to use, i.e. "%spellout-cardinal"
+}
+
+func parseSpellout(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	return parseRuleSetExpr(varname, "%spellout-cardinal", char, start, end, ptr_input)
+}
+
+// parseRuleSetExpr parses an expression formatted by a rule set, whose name may be given as its style.
+func parseRuleSetExpr(varname, ruleSet string, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	result := new(spelloutExpr)
+	result.key = varname
+	result.ruleSet = ruleSet
+
+	if char == CloseChar {
+		return result, start, nil
+	}
+
+	style, pos, err := readStyle(start+1, end, ptr_input)
+	if err != nil {
+		return nil, pos, err
+	}
+
+	switch {
+	case style == "":
+		return nil, pos, ErrMissingStyle
+
+	case !strings.HasPrefix(style, "%") || strings.HasPrefix(style, "%%") || strings.ContainsAny(style, " \t\r\n"):
+		return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+	}
+
+	result.ruleSet = style
+	return result, pos, nil
+}
+
+// formatSpellout is the format function associated with the "spellout" and "ordinal" types.
+//
+// It will returns an error if :
+// - the associated value is not numeric or is a string that can't be parsed as a number
+// - the rule set is not defined for the culture
+// - the pluralFunc is not defined (MessageFormat.getNamedKey) and a rule of the set needs it
+//
+// It will writes nothing if its key can't be found in the given map
+func formatSpellout(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+	o := expr.(*spelloutExpr)
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	} else if !ok || v == nil {
+		return nil
+	}
+
+	value, err := toDecimal(v)
+	if err != nil {
+		return err
+	}
+
+	sets, culture := getRuleSets(ptr_mf.culture)
+
+	set, ok := sets[o.ruleSet]
+	if !ok {
+		return fmt.Errorf("%w: `%s`", ErrUnknownRuleSet, o.ruleSet)
+	}
+	return set.format(ptr_output, value, sets, ptr_mf.pluralFor(culture))
+}
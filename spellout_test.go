@@ -0,0 +1,119 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestSpellout(t *testing.T) {
+	doTest(t, Test{
+		"{N, spellout}",
+		[]Expectation{
+			{map[string]interface{}{"N": 0}, "zero"},
+			{map[string]interface{}{"N": 42}, "forty-two"},
+			{map[string]interface{}{"N": 100}, "one hundred"},
+			{map[string]interface{}{"N": 1234}, "one thousand two hundred thirty-four"},
+			{map[string]interface{}{"N": 2000015}, "two million fifteen"},
+			{map[string]interface{}{"N": -7}, "minus seven"},
+			{map[string]interface{}{"N": 3.14}, "three point one four"},
+			{map[string]interface{}{"N": "1.50"}, "one point five"},
+			{map[string]interface{}{"N": int64(2000000000000000)}, "2,000,000,000,000,000"},
+			{nil, ""},
+		},
+	})
+
+	doTest(t, Test{
+		"{N, spellout, %spellout-cardinal}",
+		[]Expectation{
+			{map[string]interface{}{"N": 19}, "nineteen"},
+		},
+	})
+
+	doTestException(
+		t,
+		"{N, spellout, %spellout-ordinal}",
+		map[string]interface{}{"N": 1},
+		"UnknownRuleSet: `%spellout-ordinal`",
+	)
+
+	doTestException(
+		t,
+		"{N, spellout}",
+		map[string]interface{}{"N": "many"},
+		"Number: Invalid value: `many`",
+	)
+}
+
+func TestSpelloutCulture(t *testing.T) {
+	doTestWithCulture(t, "fr", Test{
+		"{N, spellout}",
+		[]Expectation{
+			{map[string]interface{}{"N": 21}, "vingt-et-un"},
+			{map[string]interface{}{"N": 42}, "quarante-deux"},
+			{map[string]interface{}{"N": 71}, "soixante-et-onze"},
+			{map[string]interface{}{"N": 80}, "quatre-vingts"},
+			{map[string]interface{}{"N": 97}, "quatre-vingt-dix-sept"},
+			{map[string]interface{}{"N": 200}, "deux cents"},
+			{map[string]interface{}{"N": 201}, "deux cent un"},
+			{map[string]interface{}{"N": 80000}, "quatre-vingt mille"},
+			{map[string]interface{}{"N": 200000}, "deux cent mille"},
+			{map[string]interface{}{"N": 280080}, "deux cent quatre-vingt mille quatre-vingts"},
+			{map[string]interface{}{"N": 97000}, "quatre-vingt-dix-sept mille"},
+			{map[string]interface{}{"N": 200000000}, "deux cents millions"},
+			{map[string]interface{}{"N": 1000000}, "un million"},
+			{map[string]interface{}{"N": 3000000}, "trois millions"},
+			{map[string]interface{}{"N": 2.5}, "deux virgule cinq"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{N, spellout}",
+		[]Expectation{
+			{map[string]interface{}{"N": 1}, "eins"},
+			{map[string]interface{}{"N": 21}, "einundzwanzig"},
+			{map[string]interface{}{"N": 115}, "einhundertfünfzehn"},
+			{map[string]interface{}{"N": 2000000}, "zwei Millionen"},
+		},
+	})
+
+	doTestWithCulture(t, "es", Test{
+		"{N, spellout}",
+		[]Expectation{
+			{map[string]interface{}{"N": 100}, "cien"},
+			{map[string]interface{}{"N": 145}, "ciento cuarenta y cinco"},
+			{map[string]interface{}{"N": 2022}, "dos mil veintidós"},
+			{map[string]interface{}{"N": 21000}, "veintiún mil"},
+			{map[string]interface{}{"N": 31001}, "treinta y un mil uno"},
+			{map[string]interface{}{"N": 101000}, "ciento un mil"},
+			{map[string]interface{}{"N": 100000}, "cien mil"},
+			{map[string]interface{}{"N": 21000000}, "veintiún millones"},
+			{map[string]interface{}{"N": 1000000000}, "mil millones"},
+			{map[string]interface{}{"N": 2000000000}, "dos mil millones"},
+			{map[string]interface{}{"N": 21000000000}, "veintiún mil millones"},
+			{map[string]interface{}{"N": 1021000000}, "mil veintiún millones"},
+			{map[string]interface{}{"N": 21}, "veintiuno"},
+		},
+	})
+}
+
+func TestSpelloutParseException(t *testing.T) {
+	doTestParseException(t, "{N, spellout, }", "ParseError: `MissingStyle` at 14")
+	doTestParseException(t, "{N, spellout, cardinal}", "ParseError: `InvalidStyle: `cardinal`` at 22")
+	doTestParseException(t, "{N, spellout, %%ein}", "ParseError: `InvalidStyle: `%%ein`` at 19")
+}
+
+func TestRuleSets(t *testing.T) {
+	for _, input := range []string{
+		"0: zero;",
+		"%a: 0: zero[;",
+		"%a: 0: <%b;",
+		"%a: x: zero;",
+		"%a: 10/1: ten;",
+		"%a: 0: =%b=;",
+		"%a: 0: $(cardinal,one{x});",
+		"%a: 0: $(plural,other{x})$;",
+	} {
+		if _, err := compileRuleSets(input); err == nil {
+			t.Errorf("`%s` should threw an error", input)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package messageformat
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructTag is the struct tag used to rename (i.e. `mf:"count"`) or ignore (`mf:"-"`) a field in FormatStruct.
+const StructTag = "mf"
+
+// FormatStruct formats the message with the exported fields of a struct (or a pointer to a struct) as arguments.
+//
+// A field is named after its StructTag or, lacking one, its own name. The fields of an embedded struct are
+// promoted as the Go language does, and a pointer field is replaced by the value it points to (nil being a
+// missing argument).
+func (x *MessageFormat) FormatStruct(v interface{}, options ...FormatOption) (string, error) {
+	data, err := structToMap(v)
+	if err != nil {
+		return "", err
+	}
+	return x.FormatMap(data, options...)
+}
+
+// structToMap converts a struct into a map of arguments, as described by FormatStruct.
+//
+// It will returns an error if the value is not a struct or a non nil pointer to a struct.
+func structToMap(v interface{}) (map[string]interface{}, error) {
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Ptr && !value.IsNil() {
+		value = value.Elem()
+	}
+
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("Struct: %w: %T", ErrUnsupportedType, v)
+	}
+
+	result := make(map[string]interface{})
+	seen := make(map[string]bool)
+	visited := make(map[reflect.Type]bool)
+
+	// the fields of a depth shadow those of the embedded structs, which are processed at the next depth
+	for level := []reflect.Value{value}; len(level) != 0; {
+		var next []reflect.Value
+		fields := make(map[string]interface{})
+		ambiguous := make(map[string]bool)
+
+		// as in Go, a struct embedded at a lower depth than its type was already seen adds no field (i.e. a
+		// struct embedding a pointer to its own type)
+		for _, s := range level {
+			visited[s.Type()] = true
+		}
+
+		for _, s := range level {
+			t := s.Type()
+
+			for i := 0; i < t.NumField(); i++ {
+				field := t.Field(i)
+
+				name := field.Tag.Get(StructTag)
+				if name == "-" {
+					continue
+				} else if j := strings.IndexByte(name, ','); j != -1 {
+					name = name[:j]
+				}
+
+				f := s.Field(i)
+				if field.Anonymous && name == "" {
+					if f.Kind() == reflect.Ptr {
+						if f.IsNil() {
+							continue
+						}
+						f = f.Elem()
+					}
+
+					if f.Kind() == reflect.Struct {
+						if !visited[f.Type()] {
+							next = append(next, f)
+						}
+						continue
+					}
+				}
+
+				if field.PkgPath != "" {
+					continue
+				} else if name == "" {
+					name = field.Name
+				}
+
+				if seen[name] {
+					continue
+				} else if _, ok := fields[name]; ok {
+					ambiguous[name] = true
+					continue
+				}
+
+				for f.Kind() == reflect.Ptr && !f.IsNil() {
+					f = f.Elem()
+				}
+
+				if f.Kind() == reflect.Ptr {
+					fields[name] = nil
+				} else {
+					fields[name] = f.Interface()
+				}
+			}
+		}
+
+		// as in Go, a name found twice at the same depth is not promoted
+		for name, v := range fields {
+			seen[name] = true
+			if !ambiguous[name] {
+				result[name] = v
+			}
+		}
+		level = next
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package messageformat
+
+import (
+	"fmt"
+	"testing"
+)
+
+type testOwner struct {
+	Name   string
+	Gender string `mf:"gender"`
+}
+
+type testAuthor struct {
+	Name   string
+	Gender string
+}
+
+type testFolder struct {
+	*testOwner
+	Count   int `mf:"count"`
+	Size    *float64
+	Shared  *int
+	Name    string `mf:"-"`
+	private string
+}
+
+type testNode struct {
+	*testNode
+	Name string
+}
+
+func doTestStruct(t *testing.T, input string, v interface{}, expected string) {
+	mf, err := doParse(input)
+	if err != nil {
+		t.Errorf("Unexpected parse failure: `%s`", err.Error())
+	} else if result, err := mf.FormatStruct(v); err != nil {
+		t.Errorf("`%s` threw <%s>", input, err)
+	} else if result != expected {
+		t.Errorf("Expecting <%v> but got <%v>", expected, result)
+	} else if testing.Verbose() {
+		fmt.Printf("- Got expected value <%s>\n", result)
+	}
+}
+
+func TestFormatStruct(t *testing.T) {
+	input := "{Name} has {count, plural, one{# file} other{# files}} in {gender, select, female{her} male{his} other{their}} folder ({Size, number}|{Shared}|{private})."
+	size := 1234.5
+
+	doTestStruct(t, input, testFolder{
+		testOwner: &testOwner{Name: "Alice", Gender: "female"},
+		Count:     1,
+		Size:      &size,
+		Name:      "ignored",
+		private:   "private",
+	}, "Alice has 1 file in her folder (1,234.5||).")
+
+	doTestStruct(t, input, &testFolder{Count: 1500}, " has 1,500 files in their folder (||).")
+
+	doTestStruct(t, "{Name}", struct {
+		Name string
+		testOwner
+	}{"Bob", testOwner{Name: "Alice"}}, "Bob")
+
+	doTestStruct(t, "{Name}|{Gender}", struct {
+		testOwner
+		testAuthor
+	}{testOwner{Name: "Alice"}, testAuthor{Name: "Bob", Gender: "male"}}, "|male")
+
+	// an embedded pointer to the struct's own type is not walked again
+	self := &testNode{Name: "root"}
+	self.testNode = self
+	doTestStruct(t, "{Name}", self, "root")
+
+	mf, _ := doParse("{N}")
+	_, err := mf.FormatStruct(map[string]interface{}{"N": 1})
+	doTestError(t, "Struct: Unsupported type: map[string]interface {}", err)
+
+	_, err = mf.FormatStruct((*testFolder)(nil))
+	doTestError(t, "Struct: Unsupported type: *messageformat.testFolder", err)
+}
@@ -0,0 +1,130 @@
+package messageformat
+
+import (
+	"fmt"
+	"strings"
+)
+
+type unitExpr struct {
+	key   string
+	unit  string // i.e. "kilometer", "kilometer-per-hour"
+	width string // "long", "short" or "narrow"
+}
+
+// measureTypes lists the types prefixing the units of a "measure-unit" skeleton stem (i.e. "length-kilometer").
+var measureTypes = map[string]bool{
+	"duration":    true,
+	"length":      true,
+	"mass":        true,
+	"speed":       true,
+	"temperature": true,
+	"volume":      true,
+}
+
+// isMeasureUnit returns true if the patterns of the unit are known.
+func isMeasureUnit(unit string) bool {
+	_, ok := unitData["en"]["long"][unit]
+	return ok
+}
+
+func parseUnit(varname string, _ *Parser, char rune, start, end int, ptr_input *[]rune) (Expression, int, error) {
+	if char == CloseChar {
+		return nil, start, ErrMissingStyle
+	}
+
+	style, pos, err := readStyle(start+1, end, ptr_input)
+	if err != nil {
+		return nil, pos, err
+	} else if style == "" {
+		return nil, pos, ErrMissingStyle
+	}
+
+	result := new(unitExpr)
+	result.key = varname
+	result.width = "short"
+
+	for _, token := range strings.Fields(style) {
+		switch {
+		case token == "long", token == "short", token == "narrow":
+			result.width = token
+
+		case isMeasureUnit(token) && result.unit == "":
+			result.unit = token
+
+		default:
+			return nil, pos, fmt.Errorf("%w: `%s`", ErrInvalidStyle, style)
+		}
+	}
+
+	if result.unit == "" {
+		return nil, pos, ErrMissingUnit
+	}
+	return result, pos, nil
+}
+
+// formatUnit is the format function associated with the "unit" type.
+//
+// It will returns an error if :
+// - the associated value is not numeric or is a string that can't be parsed as a number
+// - the pluralFunc is not defined (MessageFormat.getNamedKey)
+//
+// It will writes nothing if its key can't be found in the given map
+func formatUnit(expr Expression, ptr_output Writer, data *map[string]interface{}, ptr_mf *MessageFormat, _ string) error {
+	o := expr.(*unitExpr)
+
+	v, ok, err := lookup(*data, o.key)
+	if err != nil {
+		return err
+	} else if !ok || v == nil {
+		return nil
+	}
+
+	value, err := toDecimal(v)
+	if err != nil {
+		return err
+	}
+
+	symbols := getNumberSymbols(ptr_mf.culture)
+
+	result, err := formatMeasure(value, symbols.patterns["decimal"], symbols, o.unit, o.width, ptr_mf)
+	if err != nil {
+		return err
+	}
+	ptr_output.WriteString(result)
+	return nil
+}
+
+// formatMeasure formats a value with the number pattern, then inserts it in the pattern of the unit chosen by
+// the plural category of the rounded value.
+func formatMeasure(value decimal, pattern *numberPattern, symbols *numberSymbols, unit, width string, ptr_mf *MessageFormat) (string, error) {
+	number, err := pattern.format(value, symbols, ptr_mf)
+	if err != nil {
+		return "", err
+	}
+
+	if pattern.scale != nil {
+		value.multiply(*pattern.scale)
+	}
+	pattern.round(&value)
+	value.trim(pattern.minFrac)
+
+	patterns, culture := getUnitPatterns(ptr_mf.culture, width, unit)
+
+	result, err := countPattern(patterns, value, ptr_mf.pluralFor(culture))
+	if err != nil {
+		return "", err
+	}
+	return replaceArgs(result, number), nil
+}
+
+// unitWidth returns the width of the unit patterns matching the "unit-width-*" skeleton stem of the pattern.
+func (x *numberPattern) unitWidth() string {
+	switch x.currencyDisplay {
+	case "full-name":
+		return "long"
+
+	case "narrow":
+		return "narrow"
+	}
+	return "short"
+}
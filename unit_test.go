@@ -0,0 +1,77 @@
+package messageformat
+
+import (
+	"testing"
+)
+
+func TestUnit(t *testing.T) {
+	doTest(t, Test{
+		"{D, unit, kilometer}|{D, unit, kilometer long}|{D, unit, narrow kilometer}",
+		[]Expectation{
+			{map[string]interface{}{"D": 5}, "5 km|5 kilometers|5km"},
+			{map[string]interface{}{"D": 1}, "1 km|1 kilometer|1km"},
+			{map[string]interface{}{"D": 1234.5678}, "1,234.568 km|1,234.568 kilometers|1,234.568km"},
+			{nil, "||"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, unit, foot long}|{D, unit, celsius}|{D, unit, mile-per-hour long}",
+		[]Expectation{
+			{map[string]interface{}{"D": 1}, "1 foot|1°C|1 mile per hour"},
+			{map[string]interface{}{"D": 2}, "2 feet|2°C|2 miles per hour"},
+		},
+	})
+
+	doTest(t, Test{
+		"{D, number, ::unit/kilometer}|{D, number, ::measure-unit/length-kilometer unit-width-full-name}|{D, number, ::unit/pound unit-width-full-name .0}|{D, number, ::unit/kilogram unit-width-narrow precision-integer}",
+		[]Expectation{
+			{map[string]interface{}{"D": 1}, "1 km|1 kilometer|1.0 pounds|1kg"},
+			{map[string]interface{}{"D": 0.99}, "0.99 km|0.99 kilometers|1.0 pounds|1kg"},
+			{map[string]interface{}{"D": 12000}, "12,000 km|12,000 kilometers|12,000.0 pounds|12,000kg"},
+		},
+	})
+
+	doTestException(
+		t,
+		"{D, unit, meter}",
+		map[string]interface{}{"D": "far"},
+		"Number: Invalid value: `far`",
+	)
+}
+
+func TestUnitCulture(t *testing.T) {
+	doTestWithCulture(t, "fr", Test{
+		"{D, unit, kilometer long}|{D, unit, kilometer}|{D, number, ::unit/kilometer-per-hour}",
+		[]Expectation{
+			{map[string]interface{}{"D": 5}, "5 kilomètres|5\u00a0km|5\u00a0km/h"},
+			{map[string]interface{}{"D": 1.5}, "1,5 kilomètre|1,5\u00a0km|1,5\u00a0km/h"},
+		},
+	})
+
+	doTestWithCulture(t, "de", Test{
+		"{D, unit, mile long}",
+		[]Expectation{
+			{map[string]interface{}{"D": 1}, "1 Meile"},
+			{map[string]interface{}{"D": 3}, "3 Meilen"},
+		},
+	})
+
+	// the "en" patterns are chosen with the "en" plural rules for a culture without patterns
+	doTestWithCulture(t, "ja", Test{
+		"{D, number, ::measure-unit/length-kilometer unit-width-full-name}|{D, unit, foot long}|{D, duration, long}",
+		[]Expectation{
+			{map[string]interface{}{"D": 1}, "1 kilometer|1 foot|1 second"},
+			{map[string]interface{}{"D": 2}, "2 kilometers|2 feet|2 seconds"},
+		},
+	})
+}
+
+func TestUnitParseException(t *testing.T) {
+	doTestParseException(t, "{D, unit}", "ParseError: `MissingStyle` at 8")
+	doTestParseException(t, "{D, unit, long}", "ParseError: `MissingUnit` at 14")
+	doTestParseException(t, "{D, unit, parsec}", "ParseError: `InvalidStyle: `parsec`` at 16")
+	doTestParseException(t, "{D, unit, meter foot}", "ParseError: `InvalidStyle: `meter foot`` at 20")
+	doTestParseException(t, "{D, number, ::unit/parsec}", "ParseError: `InvalidSkeleton: `unit/parsec`` at 25")
+	doTestParseException(t, "{D, number, ::measure-unit/kilometer}", "ParseError: `InvalidSkeleton: `measure-unit/kilometer`` at 36")
+}
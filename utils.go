@@ -11,6 +11,24 @@ func isWhitespace(char rune) bool {
 	return char == ' ' || char == '\r' || char == '\n' || char == '\t'
 }
 
+// isSyntaxChar returns true if the char can start a quoted literal text in the ApostropheDoubleOptional mode.
+//
+// As with ICU, the braces are syntax chars in any message, whereas a PoundChar only is in a choice of a "plural" or
+// "selectordinal" argument and a '|' in a choice of a "choice" argument (see node.parent).
+func isSyntaxChar(char rune, parent string) bool {
+	switch char {
+	case OpenChar, CloseChar:
+		return true
+
+	case PoundChar:
+		return parent == "plural" || parent == "selectordinal"
+
+	case '|':
+		return parent == "choice"
+	}
+	return false
+}
+
 // whitespace traverses the input until a non-whitespace char is encountered.
 func whitespace(start, end int, ptr_input *[]rune) (rune, int) {
 	input := *ptr_input
@@ -33,73 +51,80 @@ func whitespace(start, end int, ptr_input *[]rune) (rune, int) {
 
 // toString retrieves a value from the given map and tries to return a string representation.
 //
-// It will returns an error if the value's type is not <nil/string/bool/numeric/time.Duration/fmt.Stringer>.
+// It will returns an error if the key is a path which can't be resolved (see lookup)
+// or if the value's type is not <nil/string/bool/numeric/time.Duration/fmt.Stringer>.
 func toString(data map[string]interface{}, key string) (string, error) {
-	if v, ok := data[key]; ok {
-		switch t := v.(type) {
-		default:
-			return "", fmt.Errorf("toString: Unsupported type: %T", v)
+	v, _, err := lookup(data, key)
+	if err != nil {
+		return "", err
+	}
+	return valueToString(v)
+}
 
-		case nil:
-			return "", nil
+// valueToString tries to return a string representation of a value, as described by toString.
+func valueToString(v interface{}) (string, error) {
+	switch t := v.(type) {
+	default:
+		return "", fmt.Errorf("toString: %w: %T", ErrUnsupportedType, v)
 
-		case bool:
-			return strconv.FormatBool(t), nil
+	case nil:
+		return "", nil
 
-		case string:
-			return t, nil
+	case bool:
+		return strconv.FormatBool(t), nil
 
-		case int:
-			return fmt.Sprintf("%d", t), nil
+	case string:
+		return t, nil
 
-		case int8:
-			return strconv.FormatInt(int64(t), 10), nil
+	case int:
+		return fmt.Sprintf("%d", t), nil
 
-		case int16:
-			return strconv.FormatInt(int64(t), 10), nil
+	case int8:
+		return strconv.FormatInt(int64(t), 10), nil
 
-		case int32:
-			return strconv.FormatInt(int64(t), 10), nil
+	case int16:
+		return strconv.FormatInt(int64(t), 10), nil
 
-		case int64:
-			return strconv.FormatInt(t, 10), nil
+	case int32:
+		return strconv.FormatInt(int64(t), 10), nil
 
-		case uint:
-			return strconv.FormatUint(uint64(t), 10), nil
+	case int64:
+		return strconv.FormatInt(t, 10), nil
 
-		case uint8:
-			return strconv.FormatUint(uint64(t), 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(t), 10), nil
 
-		case uint16:
-			return strconv.FormatUint(uint64(t), 10), nil
+	case uint8:
+		return strconv.FormatUint(uint64(t), 10), nil
 
-		case uint32:
-			return strconv.FormatUint(uint64(t), 10), nil
+	case uint16:
+		return strconv.FormatUint(uint64(t), 10), nil
 
-		case uint64:
-			return strconv.FormatUint(t, 10), nil
+	case uint32:
+		return strconv.FormatUint(uint64(t), 10), nil
 
-		case float32:
-			return strconv.FormatFloat(float64(t), 'f', -1, 32), nil
+	case uint64:
+		return strconv.FormatUint(t, 10), nil
 
-		case float64:
-			return strconv.FormatFloat(t, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32), nil
 
-		case complex64:
-			return fmt.Sprintf("%g", t), nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
 
-		case complex128:
-			return fmt.Sprintf("%g", t), nil
+	case complex64:
+		return fmt.Sprintf("%g", t), nil
 
-		case uintptr:
-			return fmt.Sprintf("%08x", t), nil
+	case complex128:
+		return fmt.Sprintf("%g", t), nil
 
-		case time.Duration:
-			return t.String(), nil
+	case uintptr:
+		return fmt.Sprintf("%08x", t), nil
 
-		case fmt.Stringer:
-			return t.String(), nil
-		}
+	case time.Duration:
+		return t.String(), nil
+
+	case fmt.Stringer:
+		return t.String(), nil
 	}
-	return "", nil
 }
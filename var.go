@@ -1,11 +1,10 @@
 package messageformat
 
 import (
-	"bytes"
-	"errors"
+	"unicode"
 )
 
-func formatVar(expr Expression, ptr_output *bytes.Buffer, data *map[string]interface{}, _ *MessageFormat, _ string) error {
+func formatVar(expr Expression, ptr_output Writer, data *map[string]interface{}, _ *MessageFormat, _ string) error {
 	value, err := toString(*data, expr.(string))
 	if err != nil {
 		return err
@@ -14,7 +13,11 @@ func formatVar(expr Expression, ptr_output *bytes.Buffer, data *map[string]inter
 	return nil
 }
 
-func readVar(start, end int, ptr_input *[]rune) (string, rune, int, error) {
+// readVar reads an argument (or a type) name.
+//
+// With extended set to false, a name only holds the [_0-9a-zA-Z] chars, else it holds any Unicode identifier
+// char (see isIdentifierChar) and hyphens. In both cases, a name can be a path (see lookup).
+func readVar(start, end int, ptr_input *[]rune, extended bool) (string, rune, int, error) {
 	char, pos := whitespace(start, end, ptr_input)
 	fc_pos, lc_pos := pos, pos
 	input := *ptr_input
@@ -22,11 +25,14 @@ func readVar(start, end int, ptr_input *[]rune) (string, rune, int, error) {
 	for pos < end {
 		switch char {
 		default:
-			// [_0-9a-zA-Z]+
-			if char != '_' && (char < '0' || char > '9') && (char < 'A' || char > 'Z') && (char < 'a' || char > 'z') {
-				return "", char, pos, errors.New("InvalidFormat")
+			// [_0-9a-zA-Z]+(\.[_0-9a-zA-Z]+)*
+			if char != '_' && (char < '0' || char > '9') && (char < 'A' || char > 'Z') && (char < 'a' || char > 'z') && char != PathChar &&
+				!(extended && (char == '-' || isIdentifierChar(char))) {
+				return "", char, pos, ErrInvalidFormat
 			} else if pos != lc_pos { // non continu (inner whitespace)
-				return "", char, pos, errors.New("InvalidFormat")
+				return "", char, pos, ErrInvalidFormat
+			} else if char == PathChar && (pos == fc_pos || input[pos-1] == PathChar) { // empty path segment
+				return "", char, pos, ErrInvalidFormat
 			}
 
 			lc_pos = pos + 1
@@ -41,11 +47,20 @@ func readVar(start, end int, ptr_input *[]rune) (string, rune, int, error) {
 			char, pos = whitespace(pos+1, end, ptr_input)
 
 		case PartChar, CloseChar:
+			if lc_pos != fc_pos && input[lc_pos-1] == PathChar { // empty last path segment
+				return "", char, lc_pos - 1, ErrInvalidFormat
+			}
 			return string(input[fc_pos:lc_pos]), char, pos, nil
 
 		case OpenChar:
-			return "", char, pos, errors.New("InvalidExpr")
+			return "", char, pos, ErrInvalidExpr
 		}
 	}
-	return "", char, pos, errors.New("UnbalancedBraces")
+	return "", char, pos, ErrUnbalancedBraces
+}
+
+// isIdentifierChar returns true if the char can continue a Unicode identifier (UAX #31 ID_Continue).
+func isIdentifierChar(char rune) bool {
+	return unicode.In(char, unicode.L, unicode.Nl, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc, unicode.Other_ID_Start, unicode.Other_ID_Continue) &&
+		!unicode.In(char, unicode.Pattern_Syntax, unicode.Pattern_White_Space)
 }
@@ -61,6 +61,39 @@ func TestVar(t *testing.T) {
 	)
 }
 
+func TestUnicodeNames(t *testing.T) {
+	doTestParseException(t, "{件数}", "ParseError: `InvalidFormat` at 1")
+	doTestParseException(t, "{first-name}", "ParseError: `InvalidFormat` at 6")
+
+	o, _ := New()
+	if err := o.SetNameMode(NameMode(42)); err == nil {
+		t.Errorf("Expecting exception <UnknownNameMode> but got none")
+	}
+	o.SetNameMode(UnicodeNames)
+
+	doTestWithParser(t, o, Test{
+		"{件数, plural, one{# ファイル} other{# ファイル}} - {first-name} {user.last-name} {Ωmega_2}",
+		[]Expectation{
+			{map[string]interface{}{
+				"件数":         3,
+				"first-name": "Jane",
+				"user":       map[string]string{"last-name": "Doe"},
+				"Ωmega_2":    "!",
+			}, "3 ファイル - Jane Doe !"},
+		},
+	})
+
+	for _, input := range []string{"{a+b}", "{a b}", "{a:b}", "{a→b}", "{a|b}"} {
+		if _, err := o.Parse(input); err == nil {
+			t.Errorf("`%s` should threw <InvalidFormat>", input)
+		}
+	}
+
+	if _, err := o.Parse("{N, plu-ral}"); err == nil {
+		t.Errorf("`{N, plu-ral}` should threw <InvalidFormat>")
+	}
+}
+
 func BenchmarkVar(b *testing.B) {
 	doBenchmarkExecute(
 		b,